@@ -1,22 +1,60 @@
 package crypto
 
-import "strconv"
-
-type (
-	BookEntry []string
+import (
+	"encoding/json"
+	"fmt"
+	"time"
 )
 
-func (be *BookEntry) Price() float64 {
-	out, _ := strconv.ParseFloat((*be)[0], 64)
-	return out
+type BookEntry struct {
+	Price  Decimal // price level
+	Size   Decimal // total quantity at this price level
+	Orders int     // number of orders at this price level
 }
 
-func (be *BookEntry) Size() float64 {
-	out, _ := strconv.ParseFloat((*be)[1], 64)
-	return out
+func (be *BookEntry) UnmarshalJSON(data []byte) error {
+	// the exchange sends [price, size, number of orders], either as strings or as numbers
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 2 {
+		return fmt.Errorf("invalid book entry: %s", string(data))
+	}
+	parse := func(elem json.RawMessage) (Decimal, error) {
+		var d Decimal
+		err := d.UnmarshalJSON(elem)
+		return d, err
+	}
+	var err error
+	if be.Price, err = parse(raw[0]); err != nil {
+		return fmt.Errorf("invalid book entry price: %w", err)
+	}
+	if be.Size, err = parse(raw[1]); err != nil {
+		return fmt.Errorf("invalid book entry size: %w", err)
+	}
+	be.Orders = 0
+	if len(raw) > 2 {
+		orders, err := parse(raw[2])
+		if err != nil {
+			return fmt.Errorf("invalid book entry number of orders: %w", err)
+		}
+		be.Orders = int(orders.IntPart())
+	}
+	return nil
 }
 
 type OrderBook struct {
-	Bids []BookEntry `json:"bids"`
-	Asks []BookEntry `json:"asks"`
+	Bids         []BookEntry `json:"bids"`
+	Asks         []BookEntry `json:"asks"`
+	Timestamp    int64       `json:"t"`            // timestamp of the data
+	UpdateId     int64       `json:"u,omitempty"`  // sequence number of this update, streaming only
+	PrevUpdateId int64       `json:"pu,omitempty"` // sequence number of the previous update, streaming deltas only
+}
+
+func (book *OrderBook) GetTimestamp() time.Time {
+	if book.Timestamp > 0 {
+		return time.Unix(book.Timestamp/1000, (book.Timestamp%1000)*int64(time.Millisecond))
+	}
+	return time.Time{}
 }
@@ -0,0 +1,26 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBookEntry(t *testing.T) {
+	var book OrderBook
+	if err := json.Unmarshal([]byte(`{"bids":[["9668.44","0.006325","1"]],"asks":[[9697.0,0.68251,3]],"t":1591704180270}`), &book); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if !book.Bids[0].Price.Equal(dec("9668.44")) || !book.Bids[0].Size.Equal(dec("0.006325")) || book.Bids[0].Orders != 1 {
+		t.Errorf("unexpected bid: %+v", book.Bids[0])
+	}
+	if !book.Asks[0].Price.Equal(dec("9697")) || !book.Asks[0].Size.Equal(dec("0.68251")) || book.Asks[0].Orders != 3 {
+		t.Errorf("unexpected ask: %+v", book.Asks[0])
+	}
+	if book.GetTimestamp().UnixNano() != 1591704180270*1000000 {
+		t.Errorf("unexpected timestamp: %v", book.GetTimestamp())
+	}
+
+	if err := json.Unmarshal([]byte(`{"bids":[["abc","1","1"]]}`), &book); err == nil {
+		t.Error("Unmarshal() did not return an error for an invalid price")
+	}
+}
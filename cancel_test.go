@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"testing"
+)
+
+func TestCancelAllEverywhere(t *testing.T) {
+	var cancelled []string
+
+	server := newTestServer(t, map[string]testHandler{"": func(params map[string]interface{}) interface{} {
+		if symbol, ok := params["instrument_name"]; ok {
+			cancelled = append(cancelled, symbol.(string))
+			return nil
+		}
+		return map[string]interface{}{
+			"count": 3,
+			"order_list": []map[string]interface{}{
+				{"order_id": "1", "instrument_name": "ETH_CRO"},
+				{"order_id": "2", "instrument_name": "BTC_USDT"},
+				{"order_id": "3", "instrument_name": "ETH_CRO"},
+			},
+		}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	result, err := client.CancelAllEverywhere()
+	if err != nil {
+		t.Fatalf("CancelAllEverywhere() failed: %v", err)
+	}
+
+	if len(result) != 2 || result[0].Symbol != "BTC_USDT" || result[1].Symbol != "ETH_CRO" || result[1].Orders != 2 {
+		t.Errorf("CancelAllEverywhere() returned %+v", result)
+	}
+	if len(cancelled) != 2 {
+		t.Errorf("CancelAllEverywhere() cancelled %v, expected BTC_USDT and ETH_CRO", cancelled)
+	}
+}
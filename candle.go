@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Interval string
+
+const (
+	MINUTE_1  Interval = "1m"
+	MINUTE_5  Interval = "5m"
+	MINUTE_15 Interval = "15m"
+	MINUTE_30 Interval = "30m"
+	HOUR_1    Interval = "1h"
+	HOUR_4    Interval = "4h"
+	HOUR_6    Interval = "6h"
+	HOUR_12   Interval = "12h"
+	DAY_1     Interval = "1D"
+	WEEK_1    Interval = "7D"
+	WEEK_2    Interval = "14D"
+	MONTH_1   Interval = "1M"
+)
+
+type Candle struct {
+	OpenTime time.Time // start time of the candlestick
+	Open     Decimal
+	High     Decimal
+	Low      Decimal
+	Close    Decimal
+	Volume   Decimal
+}
+
+func (candle *Candle) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		T int64   `json:"t"` // start time of the candlestick (Unix timestamp in ms)
+		O Decimal `json:"o"` // open
+		H Decimal `json:"h"` // high
+		L Decimal `json:"l"` // low
+		C Decimal `json:"c"` // close
+		V Decimal `json:"v"` // volume
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	candle.OpenTime = time.Unix(raw.T/1000, (raw.T%1000)*int64(time.Millisecond))
+	candle.Open = raw.O
+	candle.High = raw.H
+	candle.Low = raw.L
+	candle.Close = raw.C
+	candle.Volume = raw.V
+	return nil
+}
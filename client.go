@@ -1,10 +1,12 @@
 package crypto
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,72 +15,43 @@ import (
 	"strconv"
 	"strings"
 	"time"
-)
-
-const endpoint = "https://api.crypto.com/v2/"
-
-type rateLimit int
-
-const (
-	RATE_LIMIT_NORMAL rateLimit = iota
-	RATE_LIMIT_COOL_DOWN
-)
-
-var RequestsPerSecond = map[rateLimit]float64{
-	RATE_LIMIT_NORMAL:    100,           // 100 req/second (default)
-	RATE_LIMIT_COOL_DOWN: 0.01666666667, // 1 req/minute
-}
-
-var (
-	cooldown    bool
-	lastRequest time.Time
-)
 
-var (
-	BeforeRequest    func(method, path string, rps float64) error = nil
-	AfterRequest     func()                                       = nil
-	OnRateLimitError func(method, path string) error              = nil
+	"github.com/shopspring/decimal"
 )
 
-func init() {
-	BeforeRequest = func(method, path string, rps float64) error {
-		elapsed := time.Since(lastRequest)
-		if cooldown {
-			cooldown = false
-			rps = RequestsPerSecond[RATE_LIMIT_COOL_DOWN]
-		} else if rps == 0 {
-			rps = RequestsPerSecond[RATE_LIMIT_NORMAL]
-		}
-		if elapsed.Seconds() < (float64(1) / rps) {
-			time.Sleep(time.Duration((float64(time.Second) / rps) - float64(elapsed)))
-		}
-		return nil
-	}
-	AfterRequest = func() {
-		lastRequest = time.Now()
-	}
-	OnRateLimitError = func(method, path string) error {
-		cooldown = true
-		return nil
-	}
-}
+const endpoint = "https://api.crypto.com/v2/"
 
 type Client struct {
-	URL        string
-	Key        string
-	Secret     string
-	httpClient *http.Client
+	URL             string
+	MarketStreamURL string
+	UserStreamURL   string
+	Key             string
+	Secret          string
+	RateLimiter     RateLimiter  // nil disables client-side rate limiting
+	RetryPolicy     *RetryPolicy // nil disables retries
+	ReconnectPolicy *RetryPolicy // nil disables reconnecting streams
+	Instruments     *Instruments // the trading rules of every instrument, loaded on first use
+	Withdrawals     *AllowList   // the only addresses (per network) CreateWithdrawal sends to. empty (or nil) refuses every withdrawal
+	httpClient      *http.Client
 }
 
 func New(apiKey, apiSecret string) *Client {
-	return &Client{
-		URL:    endpoint,
-		Key:    apiKey,
-		Secret: apiSecret,
+	client := &Client{
+		URL:             endpoint,
+		MarketStreamURL: marketStreamEndpoint,
+		UserStreamURL:   userStreamEndpoint,
+		Key:             apiKey,
+		Secret:          apiSecret,
+		RateLimiter:     NewRateLimiter(),
+		RetryPolicy:     DefaultRetryPolicy(),
+		ReconnectPolicy: DefaultReconnectPolicy(),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+	client.Instruments = newInstruments(client)
+	client.Withdrawals = &AllowList{}
+	return client
 }
 
 type Request struct {
@@ -95,7 +68,7 @@ type Response struct {
 	Result json.RawMessage `json:"result"`
 }
 
-func (client *Client) get(path string, params *url.Values) (json.RawMessage, error) {
+func (client *Client) get(ctx context.Context, path string, params *url.Values) (json.RawMessage, error) {
 	// parse the root URL
 	endpoint, err := url.Parse(client.URL)
 	if err != nil {
@@ -108,86 +81,51 @@ func (client *Client) get(path string, params *url.Values) (json.RawMessage, err
 		endpoint.RawQuery = params.Encode()
 	}
 
-	var data []byte
-	for {
-		var code int
-		code, data, err = func() (int, []byte, error) {
-			// satisfy the rate limiter
-			if err := BeforeRequest("GET", path, RequestsPerSecond[RATE_LIMIT_NORMAL]); err != nil {
-				return 0, nil, err
-			}
-			defer func() {
-				AfterRequest()
-			}()
-
-			response, err := client.httpClient.Get(endpoint.String())
-			if err != nil {
-				return 0, nil, err
-			}
-			defer response.Body.Close()
+	return client.retry(ctx, true, func() ([]byte, error) {
+		// satisfy the rate limiter
+		if err := client.wait(ctx, "GET", path, RequestsPerSecond[RATE_LIMIT_NORMAL]); err != nil {
+			return nil, err
+		}
 
-			// are we exceeding the rate limits?
-			if response.StatusCode == http.StatusTooManyRequests {
-				if err := OnRateLimitError("GET", path); err != nil {
-					return response.StatusCode, nil, err
-				}
-			}
+		request, err := http.NewRequestWithContext(ctx, "GET", endpoint.String(), nil)
+		if err != nil {
+			return nil, err
+		}
 
-			// read the body of the response into a byte array
-			body, err := ioutil.ReadAll(response.Body)
-			if err != nil {
-				return response.StatusCode, nil, err
-			}
+		response, err := client.httpClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
 
-			// is this an error?
-			status := make(map[string]interface{})
-			if json.Unmarshal(body, &status) == nil {
-				if code, ok := status["code"]; ok {
-					if code != float64(0) {
-						msg := func() string {
-							if det, ok := status["details"]; ok {
-								return fmt.Sprintf("%v", det)
-							} else if msg, ok := status["message"]; ok {
-								return fmt.Sprintf("%v", msg)
-							} else {
-								return fmt.Sprintf("%v", code)
-							}
-						}()
-						return response.StatusCode, nil, func() error {
-							if params == nil {
-								return fmt.Errorf("GET %s %s", path, msg)
-							} else {
-								return fmt.Errorf("GET %s?%s %s", path, params.Encode(), msg)
-							}
-						}()
-					}
-				}
-			}
+		// are we exceeding the rate limits?
+		if response.StatusCode == http.StatusTooManyRequests {
+			client.onRateLimitError("GET", path)
+		}
 
-			if response.StatusCode < 200 || response.StatusCode >= 300 {
-				return response.StatusCode, nil, func() error {
-					if params == nil {
-						return fmt.Errorf("GET %s %s", path, response.Status)
-					} else {
-						return fmt.Errorf("GET %s?%s %s", path, params.Encode(), response.Status)
-					}
-				}()
-			}
+		// read the body of the response into a byte array
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
 
-			var output Response
-			if err := json.Unmarshal(body, &output); err != nil {
-				return response.StatusCode, nil, err
+		// is this an error?
+		if err := checkResponse("GET", func() string {
+			if params == nil {
+				return path
 			}
+			return path + "?" + params.Encode()
+		}(), response, body); err != nil {
+			return nil, err
+		}
 
-			return response.StatusCode, output.Result, nil
-		}()
-
-		if code != http.StatusTooManyRequests {
-			break
+		var output Response
+		if err := json.Unmarshal(body, &output); err != nil {
+			return nil, err
 		}
-	}
 
-	return data, err
+		return output.Result, nil
+	})
 }
 
 func params(symbol string, page int) map[string]interface{} {
@@ -201,7 +139,148 @@ func params(symbol string, page int) map[string]interface{} {
 	return output
 }
 
-func (client *Client) post(path string, params map[string]interface{}, rps float64) ([]byte, error) {
+// paramString returns the string a parameter value contributes to the signature. Lists (e.g. the orders in
+// private/create-order-list) contribute their elements, one after another, and objects their sorted keys and values.
+func paramString(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case Decimal:
+		return v.String()
+	case []map[string]interface{}:
+		var out strings.Builder
+		for _, elem := range v {
+			out.WriteString(paramString(elem))
+		}
+		return out.String()
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var out strings.Builder
+		for _, key := range keys {
+			if v[key] != nil {
+				out.WriteString(key)
+				out.WriteString(paramString(v[key]))
+			}
+		}
+		return out.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// sign returns the HMAC-SHA256 digital signature of a request, see https://exchange-docs.crypto.com/spot/index.html#digital-signature
+func (client *Client) sign(method string, id int64, params map[string]interface{}, nonce int64) string {
+	var sig strings.Builder
+	sig.WriteString(method)                    // method
+	sig.WriteString(strconv.FormatInt(id, 10)) // id
+	sig.WriteString(client.Key)                // api_key
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := params[key]
+		if value != nil {
+			sig.WriteString(key)
+			sig.WriteString(paramString(value))
+		}
+	}
+	sig.WriteString(strconv.FormatInt(nonce, 10))
+	mac := hmac.New(sha256.New, []byte(client.Secret))
+	mac.Write([]byte(sig.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+const (
+	maxPageSize      = 200            // the maximum number of records the exchange returns per page
+	maxHistoryWindow = 24 * time.Hour // the maximum time range the exchange returns history for
+)
+
+// timestamp returns t as milliseconds since the Unix epoch.
+func timestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// paginate calls fetch with the parameters of page 0, 1, 2 and so on, until a page comes back with fewer than maxPageSize
+// items. fetch returns the number of items on its page.
+func paginate(params func(page int) map[string]interface{}, fetch func(params map[string]interface{}) (int, error)) error {
+	for page := 0; ; page++ {
+		params := params(page)
+		params["page_size"] = maxPageSize
+		count, err := fetch(params)
+		if err != nil {
+			return err
+		}
+		if count < maxPageSize {
+			return nil
+		}
+	}
+}
+
+// historyParams returns the parameters of a page of order or trade history between from and to.
+func historyParams(symbol string, from, to time.Time) func(page int) map[string]interface{} {
+	return func(page int) map[string]interface{} {
+		params := params(symbol, page)
+		params["start_ts"] = timestamp(from)
+		params["end_ts"] = timestamp(to)
+		return params
+	}
+}
+
+// walletParams returns the parameters of a page of deposit or withdrawal history. Empty (or zero) filters are left out.
+func walletParams(currency string, start, end time.Time, status string) func(page int) map[string]interface{} {
+	return func(page int) map[string]interface{} {
+		params := params("", page)
+		if currency != "" {
+			params["currency"] = currency
+		}
+		if !start.IsZero() {
+			params["start_ts"] = timestamp(start)
+		}
+		if !end.IsZero() {
+			params["end_ts"] = timestamp(end)
+		}
+		if status != "" {
+			params["status"] = status
+		}
+		return params
+	}
+}
+
+// checkRange returns an error if start is zero or not before end, e.g. a range windows would never (or needlessly) walk.
+func checkRange(start, end time.Time) error {
+	if start.IsZero() {
+		return errors.New("start time is required")
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("start time %v is not before end time %v", start, end)
+	}
+	return nil
+}
+
+// windows splits the time range between start and end into consecutive windows of at most max.
+func windows(start, end time.Time, max time.Duration, fn func(from, to time.Time) error) error {
+	for from := start; from.Before(end); from = from.Add(max) {
+		to := from.Add(max)
+		if to.After(end) {
+			to = end
+		}
+		if err := fn(from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *Client) post(ctx context.Context, path string, params map[string]interface{}, rps float64) ([]byte, error) {
 	// create the endpoint for this request
 	endpoint, err := url.Parse(client.URL)
 	if err != nil {
@@ -209,133 +288,72 @@ func (client *Client) post(path string, params map[string]interface{}, rps float
 	}
 	endpoint.Path += path
 
-	var data []byte
-	for {
-		var code int
-		code, data, err = func() (int, []byte, error) {
-			// satisfy the rate limiter
-			if err := BeforeRequest("POST", path, rps); err != nil {
-				return 0, nil, err
-			}
-			defer func() {
-				AfterRequest()
-			}()
-
-			nonce := time.Now().UnixNano() / int64(time.Millisecond/time.Nanosecond)
-
-			// generate signature
-			var sig strings.Builder
-			sig.WriteString(path)       // method
-			sig.WriteString("0")        // id
-			sig.WriteString(client.Key) // api_key
-			keys := make([]string, 0, len(params))
-			for key := range params {
-				keys = append(keys, key)
-			}
-			sort.Strings(keys)
-			for _, key := range keys {
-				value := params[key]
-				if value != nil {
-					sig.WriteString(key)
-					sig.WriteString(func(v interface{}) string {
-						if i, ok := v.(int); ok {
-							return strconv.Itoa(i)
-						}
-						if i64, ok := v.(int64); ok {
-							return strconv.FormatInt(i64, 10)
-						}
-						if f64, ok := v.(float64); ok {
-							return strconv.FormatFloat(f64, 'f', -1, 64)
-						}
-						return fmt.Sprintf("%v", v)
-					}(value))
-				}
-			}
-			sig.WriteString(strconv.FormatInt(nonce, 10))
-			mac := hmac.New(sha256.New, []byte(client.Secret))
-			mac.Write([]byte(sig.String()))
-
-			payload, err := json.Marshal(Request{
-				Id:     0,
-				Method: path,
-				ApiKey: client.Key,
-				Params: params,
-				Sig:    hex.EncodeToString(mac.Sum(nil)),
-				Nonce:  nonce,
-			})
-			if err != nil {
-				return 0, nil, err
-			}
-
-			// create the request
-			request, err := http.NewRequest("POST", endpoint.String(), strings.NewReader(string(payload)))
-			if err != nil {
-				return 0, nil, err
-			}
-			request.Header.Add("Content-Type", "application/json")
+	return client.retry(ctx, client.idempotent(path, params), func() ([]byte, error) {
+		// satisfy the rate limiter
+		if err := client.wait(ctx, "POST", path, rps); err != nil {
+			return nil, err
+		}
 
-			// submit the http request
-			response, err := client.httpClient.Do(request)
-			if err != nil {
-				return 0, nil, err
-			}
-			defer response.Body.Close()
+		nonce := time.Now().UnixNano() / int64(time.Millisecond/time.Nanosecond)
 
-			// are we exceeding the rate limits?
-			if response.StatusCode == http.StatusTooManyRequests {
-				if err = OnRateLimitError("POST", path); err != nil {
-					return response.StatusCode, nil, err
-				}
-			}
+		payload, err := json.Marshal(Request{
+			Id:     0,
+			Method: path,
+			ApiKey: client.Key,
+			Params: params,
+			Sig:    client.sign(path, 0, params, nonce),
+			Nonce:  nonce,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-			// read the body of the response into a byte array
-			body, err := ioutil.ReadAll(response.Body)
-			if err != nil {
-				return response.StatusCode, nil, err
-			}
+		// create the request
+		request, err := http.NewRequestWithContext(ctx, "POST", endpoint.String(), strings.NewReader(string(payload)))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Add("Content-Type", "application/json")
 
-			// is this an error?
-			status := make(map[string]interface{})
-			if json.Unmarshal(body, &status) == nil {
-				if code, ok := status["code"]; ok {
-					if code != float64(0) {
-						msg := func() string {
-							if det, ok := status["details"]; ok {
-								return fmt.Sprintf("%v", det)
-							} else if msg, ok := status["message"]; ok {
-								return fmt.Sprintf("%v", msg)
-							} else {
-								return fmt.Sprintf("%v", code)
-							}
-						}()
-						return response.StatusCode, nil, fmt.Errorf("POST %s %s", path, msg)
-					}
-				}
-			}
+		// submit the http request
+		response, err := client.httpClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
 
-			if response.StatusCode < 200 || response.StatusCode >= 300 {
-				return response.StatusCode, nil, fmt.Errorf("POST %s %s", path, response.Status)
-			}
+		// are we exceeding the rate limits?
+		if response.StatusCode == http.StatusTooManyRequests {
+			client.onRateLimitError("POST", path)
+		}
 
-			// unmarshal the response body
-			var output Response
-			if err = json.Unmarshal(body, &output); err != nil {
-				return response.StatusCode, nil, err
-			}
+		// read the body of the response into a byte array
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
 
-			return response.StatusCode, output.Result, nil
-		}()
+		// is this an error?
+		if err := checkResponse("POST", path, response, body); err != nil {
+			return nil, err
+		}
 
-		if code != http.StatusTooManyRequests {
-			break
+		// unmarshal the response body
+		var output Response
+		if err = json.Unmarshal(body, &output); err != nil {
+			return nil, err
 		}
-	}
 
-	return data, err
+		return output.Result, nil
+	})
 }
 
 func (client *Client) Symbols() ([]Symbol, error) {
-	raw, err := client.get("public/get-instruments", nil)
+	return client.SymbolsContext(context.Background())
+}
+
+func (client *Client) SymbolsContext(ctx context.Context) ([]Symbol, error) {
+	raw, err := client.get(ctx, "public/get-instruments", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -350,7 +368,11 @@ func (client *Client) Symbols() ([]Symbol, error) {
 }
 
 func (client *Client) Tickers() ([]Ticker, error) {
-	raw, err := client.get("public/get-ticker", nil)
+	return client.TickersContext(context.Background())
+}
+
+func (client *Client) TickersContext(ctx context.Context) ([]Ticker, error) {
+	raw, err := client.get(ctx, "public/get-ticker", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -365,9 +387,13 @@ func (client *Client) Tickers() ([]Ticker, error) {
 }
 
 func (client *Client) Ticker(symbol string) (*Ticker, error) {
+	return client.TickerContext(context.Background(), symbol)
+}
+
+func (client *Client) TickerContext(ctx context.Context, symbol string) (*Ticker, error) {
 	params := url.Values{}
 	params.Add("instrument_name", symbol)
-	raw, err := client.get("public/get-ticker", &params)
+	raw, err := client.get(ctx, "public/get-ticker", &params)
 	if err != nil {
 		return nil, err
 	}
@@ -385,9 +411,25 @@ func (client *Client) Ticker(symbol string) (*Ticker, error) {
 }
 
 func (client *Client) OrderBook(symbol string) (*OrderBook, error) {
+	return client.OrderBookContext(context.Background(), symbol)
+}
+
+func (client *Client) OrderBookContext(ctx context.Context, symbol string) (*OrderBook, error) {
+	return client.OrderBookDepthContext(ctx, symbol, 0)
+}
+
+// OrderBookDepth returns up to depth bids and asks for symbol. Zero depth returns the exchange default.
+func (client *Client) OrderBookDepth(symbol string, depth int) (*OrderBook, error) {
+	return client.OrderBookDepthContext(context.Background(), symbol, depth)
+}
+
+func (client *Client) OrderBookDepthContext(ctx context.Context, symbol string, depth int) (*OrderBook, error) {
 	params := url.Values{}
 	params.Add("instrument_name", symbol)
-	raw, err := client.get("public/get-book", &params)
+	if depth > 0 {
+		params.Add("depth", strconv.Itoa(depth))
+	}
+	raw, err := client.get(ctx, "public/get-book", &params)
 	if err != nil {
 		return nil, err
 	}
@@ -404,8 +446,59 @@ func (client *Client) OrderBook(symbol string) (*OrderBook, error) {
 	return &result.Data[0], nil
 }
 
+func (client *Client) Candlesticks(symbol string, interval Interval) ([]Candle, error) {
+	return client.CandlesticksContext(context.Background(), symbol, interval)
+}
+
+func (client *Client) CandlesticksContext(ctx context.Context, symbol string, interval Interval) ([]Candle, error) {
+	params := url.Values{}
+	params.Add("instrument_name", symbol)
+	params.Add("timeframe", string(interval))
+	raw, err := client.get(ctx, "public/get-candlestick", &params)
+	if err != nil {
+		return nil, err
+	}
+	type Result struct {
+		Data []Candle `json:"data"`
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// PublicTrades returns the most recent trades for symbol, or across all markets if symbol is empty.
+func (client *Client) PublicTrades(symbol string) ([]PublicTrade, error) {
+	return client.PublicTradesContext(context.Background(), symbol)
+}
+
+func (client *Client) PublicTradesContext(ctx context.Context, symbol string) ([]PublicTrade, error) {
+	var params *url.Values
+	if symbol != "" {
+		params = &url.Values{}
+		params.Add("instrument_name", symbol)
+	}
+	raw, err := client.get(ctx, "public/get-trades", params)
+	if err != nil {
+		return nil, err
+	}
+	type Result struct {
+		Data []PublicTrade `json:"data"`
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
 func (client *Client) Accounts() ([]Account, error) {
-	raw, err := client.post("private/get-account-summary", nil, 30)
+	return client.AccountsContext(context.Background())
+}
+
+func (client *Client) AccountsContext(ctx context.Context) ([]Account, error) {
+	raw, err := client.post(ctx, "private/get-account-summary", nil, 30)
 	if err != nil {
 		return nil, err
 	}
@@ -420,9 +513,13 @@ func (client *Client) Accounts() ([]Account, error) {
 }
 
 func (client *Client) Account(asset string) (*Account, error) {
+	return client.AccountContext(context.Background(), asset)
+}
+
+func (client *Client) AccountContext(ctx context.Context, asset string) (*Account, error) {
 	params := make(map[string]interface{})
 	params["currency"] = asset
-	raw, err := client.post("private/get-account-summary", params, 30)
+	raw, err := client.post(ctx, "private/get-account-summary", params, 30)
 	if err != nil {
 		return nil, err
 	}
@@ -439,16 +536,251 @@ func (client *Client) Account(asset string) (*Account, error) {
 	return &result.Accounts[0], nil
 }
 
-func (client *Client) CreateOrder(symbol string, side OrderSide, kind OrderType, quantity, price float64) (*string, error) { // -> (order_id, error)
+// DepositAddresses returns the addresses you can deposit currency to, one or more per network.
+func (client *Client) DepositAddresses(currency string) ([]DepositAddress, error) {
+	return client.DepositAddressesContext(context.Background(), currency)
+}
+
+func (client *Client) DepositAddressesContext(ctx context.Context, currency string) ([]DepositAddress, error) {
 	params := make(map[string]interface{})
-	params["instrument_name"] = symbol
-	params["side"] = side
-	params["type"] = kind
-	params["quantity"] = quantity
-	if kind == LIMIT || kind == STOP_LIMIT {
-		params["price"] = price
+	params["currency"] = currency
+	raw, err := client.post(ctx, "private/get-deposit-address", params, 1)
+	if err != nil {
+		return nil, err
+	}
+	type Result struct {
+		DepositAddressList []DepositAddress `json:"deposit_address_list"`
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return result.DepositAddressList, nil
+}
+
+// DepositHistory returns the deposits between start and end, oldest first. An empty currency returns the deposits of
+// every currency, and an empty status returns the deposits regardless of their status.
+func (client *Client) DepositHistory(currency string, start, end time.Time, status DepositStatus) ([]Deposit, error) {
+	return client.DepositHistoryContext(context.Background(), currency, start, end, status)
+}
+
+func (client *Client) DepositHistoryContext(ctx context.Context, currency string, start, end time.Time, status DepositStatus) ([]Deposit, error) {
+	call := func(params map[string]interface{}) ([]Deposit, error) {
+		raw, err := client.post(ctx, "private/get-deposit-history", params, 1)
+		if err != nil {
+			return nil, err
+		}
+		type Result struct {
+			DepositList []Deposit `json:"deposit_list"`
+		}
+		var result Result
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		return result.DepositList, nil
+	}
+
+	var (
+		seen   = make(map[string]bool)
+		result []Deposit
+	)
+
+	if err := paginate(walletParams(currency, start, end, string(status)), func(params map[string]interface{}) (int, error) {
+		deposits, err := call(params)
+		if err != nil {
+			return 0, err
+		}
+		for _, deposit := range deposits {
+			if !seen[deposit.Id] {
+				seen[deposit.Id] = true
+				result = append(result, deposit)
+			}
+		}
+		return len(deposits), nil
+	}); err != nil {
+		return nil, err
 	}
-	raw, err := client.post("private/create-order", params, 150)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt < result[j].CreatedAt
+	})
+
+	return result, nil
+}
+
+// CurrencyNetworks returns the networks (or chains) of every currency, keyed by currency, with their withdrawal fees,
+// minimum withdrawal amounts and whether deposits and withdrawals are enabled.
+func (client *Client) CurrencyNetworks() (map[string]Currency, error) {
+	return client.CurrencyNetworksContext(context.Background())
+}
+
+func (client *Client) CurrencyNetworksContext(ctx context.Context) (map[string]Currency, error) {
+	raw, err := client.post(ctx, "private/get-currency-networks", nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	type Result struct {
+		CurrencyMap map[string]Currency `json:"currency_map"`
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	for key, currency := range result.CurrencyMap {
+		currency.Currency = key
+		result.CurrencyMap[key] = currency
+	}
+	return result.CurrencyMap, nil
+}
+
+// CreateWithdrawal withdraws amount of currency to address, with an optional tag (or memo) and network (or chain).
+// clientWid is your own, optional, withdrawal ID; when you set it, the Client can safely retry the request. The address
+// must have been registered with client.Withdrawals.Allow for the network (or, if network is empty, the default network
+// of the currency), or CreateWithdrawal returns ErrWithdrawalNotAllowed. The amount and network are checked against
+// CurrencyNetworks, or CreateWithdrawal returns ErrInvalidWithdrawal.
+func (client *Client) CreateWithdrawal(currency string, amount Decimal, address, tag, network, clientWid string) (*Withdrawal, error) {
+	return client.CreateWithdrawalContext(context.Background(), currency, amount, address, tag, network, clientWid)
+}
+
+func (client *Client) CreateWithdrawalContext(ctx context.Context, currency string, amount Decimal, address, tag, network, clientWid string) (*Withdrawal, error) {
+	if currency == "" || address == "" {
+		return nil, fmt.Errorf("%w: currency and address are required", ErrInvalidWithdrawal)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: invalid amount: %s", ErrInvalidWithdrawal, amount)
+	}
+	currencies, err := client.CurrencyNetworksContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := currencies[currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown currency: %s", ErrInvalidWithdrawal, currency)
+	}
+	// resolve the default network, so the allow list is checked against the network we actually withdraw on
+	resolved, err := info.GetNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	network = resolved.Network
+	if !client.Withdrawals.Allowed(currency, network, address, tag) {
+		return nil, fmt.Errorf("%w: %s %s %s %s", ErrWithdrawalNotAllowed, currency, network, address, tag)
+	}
+	if err := info.CheckWithdrawal(amount, network); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	params["currency"] = currency
+	params["amount"] = number(amount)
+	params["address"] = address
+	if tag != "" {
+		params["address_tag"] = tag
+	}
+	params["network_id"] = network
+	if clientWid != "" {
+		params["client_wid"] = clientWid
+	}
+
+	raw, err := client.post(ctx, "private/create-withdrawal", params, 1)
+	if err != nil {
+		return nil, err
+	}
+	var result Withdrawal
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WithdrawalHistory returns the withdrawals between start and end, oldest first. An empty currency returns the
+// withdrawals of every currency, and an empty status returns the withdrawals regardless of their status.
+func (client *Client) WithdrawalHistory(currency string, start, end time.Time, status WithdrawalStatus) ([]Withdrawal, error) {
+	return client.WithdrawalHistoryContext(context.Background(), currency, start, end, status)
+}
+
+func (client *Client) WithdrawalHistoryContext(ctx context.Context, currency string, start, end time.Time, status WithdrawalStatus) ([]Withdrawal, error) {
+	call := func(params map[string]interface{}) ([]Withdrawal, error) {
+		raw, err := client.post(ctx, "private/get-withdrawal-history", params, 1)
+		if err != nil {
+			return nil, err
+		}
+		type Result struct {
+			WithdrawalList []Withdrawal `json:"withdrawal_list"`
+		}
+		var result Result
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		return result.WithdrawalList, nil
+	}
+
+	var (
+		seen   = make(map[string]bool)
+		result []Withdrawal
+	)
+
+	if err := paginate(walletParams(currency, start, end, string(status)), func(params map[string]interface{}) (int, error) {
+		withdrawals, err := call(params)
+		if err != nil {
+			return 0, err
+		}
+		for _, withdrawal := range withdrawals {
+			if !seen[withdrawal.Id] {
+				seen[withdrawal.Id] = true
+				result = append(result, withdrawal)
+			}
+		}
+		return len(withdrawals), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt < result[j].CreatedAt
+	})
+
+	return result, nil
+}
+
+// CreateOrder submits an order and then calls DiagnoseOrder, so it returns an error if the order was rejected or expired.
+// Use PlaceOrder if you don't want to wait for that.
+func (client *Client) CreateOrder(symbol string, side OrderSide, kind OrderType, quantity, price Decimal) (*string, error) { // -> (order_id, error)
+	return client.CreateOrderContext(context.Background(), symbol, side, kind, quantity, price)
+}
+
+func (client *Client) CreateOrderContext(ctx context.Context, symbol string, side OrderSide, kind OrderType, quantity, price Decimal) (*string, error) { // -> (order_id, error)
+	req := &OrderRequest{
+		Symbol:   symbol,
+		Side:     side,
+		Type:     kind,
+		Quantity: quantity,
+		Price: func() Decimal {
+			if kind.isLimit() {
+				return price
+			}
+			return decimal.Zero
+		}(),
+	}
+	orderId, err := client.PlaceOrderContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return orderId, client.DiagnoseOrderContext(ctx, symbol, *orderId)
+}
+
+// PlaceOrder checks the order request against the trading rules of its instrument (see CheckOrder) and submits it to the
+// exchange. Unlike CreateOrder, it returns the order id as soon as the exchange has accepted the request, without checking
+// whether the order was rejected or expired afterwards. Call DiagnoseOrder if you want to know.
+func (client *Client) PlaceOrder(req *OrderRequest) (*string, error) { // -> (order_id, error)
+	return client.PlaceOrderContext(context.Background(), req)
+}
+
+func (client *Client) PlaceOrderContext(ctx context.Context, req *OrderRequest) (*string, error) { // -> (order_id, error)
+	if err := client.CheckOrderContext(ctx, req); err != nil {
+		return nil, err
+	}
+	raw, err := client.post(ctx, "private/create-order", req.params(), 150)
 	if err != nil {
 		return nil, err
 	}
@@ -459,36 +791,45 @@ func (client *Client) CreateOrder(symbol string, side OrderSide, kind OrderType,
 	if err := json.Unmarshal(raw, &result); err != nil {
 		return nil, err
 	}
-	order, err := client.GetOrder(symbol, result.OrderId)
+	return &result.OrderId, nil
+}
+
+// DiagnoseOrder returns a descriptive error if the order was rejected or expired, or nil otherwise.
+func (client *Client) DiagnoseOrder(symbol, orderId string) error {
+	return client.DiagnoseOrderContext(context.Background(), symbol, orderId)
+}
+
+func (client *Client) DiagnoseOrderContext(ctx context.Context, symbol, orderId string) error {
+	order, err := client.GetOrderContext(ctx, symbol, orderId)
 	if err != nil {
-		return &result.OrderId, err
+		return err
 	}
 	if order.Status == ORDER_STATUS_REJECTED {
-		return &result.OrderId, fmt.Errorf("order rejected. reason: %v", order.Reason)
+		return fmt.Errorf("order rejected. reason: %v", order.Reason)
 	}
 	if order.Status == ORDER_STATUS_EXPIRED {
-		var (
-			base  = strings.Split(symbol, "/")[0]
-			quote = strings.Split(symbol, "/")[1]
-		)
-		return &result.OrderId, fmt.Errorf("cannot %v %s unit(s) of %s at %s %s. your available balance is %s %s",
-			side, strconv.FormatFloat(quantity, 'f', -1, 64), base, quote,
-			strconv.FormatFloat(func() float64 {
-				if kind == MARKET {
-					ticker, err := client.Ticker(symbol)
+		base, quote, err := ParseSymbol(symbol)
+		if err != nil {
+			return fmt.Errorf("order expired. %v", err)
+		}
+		return fmt.Errorf("cannot %v %s unit(s) of %s at %s %s. your available balance is %s %s",
+			order.Side, order.Quantity, base, quote,
+			func() Decimal {
+				if order.Type == MARKET || order.Price.IsZero() {
+					ticker, err := client.TickerContext(ctx, symbol)
 					if err == nil {
 						return ticker.Last
 					}
 				}
-				return price
-			}(), 'f', -1, 64), func() string {
-				if side == SELL {
+				return order.Price
+			}(), func() string {
+				if order.Side == SELL {
 					return base
 				}
 				return quote
-			}(), strconv.FormatFloat(func() float64 {
-				account, err := client.Account(func() string {
-					if side == SELL {
+			}(), func() Decimal {
+				account, err := client.AccountContext(ctx, func() string {
+					if order.Side == SELL {
 						return base
 					}
 					return quote
@@ -496,17 +837,188 @@ func (client *Client) CreateOrder(symbol string, side OrderSide, kind OrderType,
 				if err == nil {
 					return account.Available
 				}
-				return 0
-			}(), 'f', -1, 64))
+				return decimal.Zero
+			}())
 	}
-	return &result.OrderId, nil
+	return nil
+}
+
+// OrderResult is the outcome of one order submitted with CreateOrders.
+type OrderResult struct {
+	OrderId string // empty if the order failed
+	Err     error  // nil if the exchange accepted the order
+}
+
+// CreateOrders checks the order requests against the trading rules of their instruments (see CheckOrder) and submits
+// them in lists of up to 10 orders. It returns one OrderResult per order request, in the same order. The error is non-nil
+// if any of the orders failed.
+func (client *Client) CreateOrders(reqs []OrderRequest) ([]OrderResult, error) {
+	return client.CreateOrdersContext(context.Background(), reqs)
+}
+
+func (client *Client) CreateOrdersContext(ctx context.Context, reqs []OrderRequest) ([]OrderResult, error) {
+	output := make([]OrderResult, len(reqs))
+
+	// the indices of the orders that pass validation
+	var valid []int
+	for i := range reqs {
+		if err := client.CheckOrderContext(ctx, &reqs[i]); err != nil {
+			output[i].Err = err
+		} else {
+			valid = append(valid, i)
+		}
+	}
+
+	for len(valid) > 0 {
+		chunk := valid
+		if len(chunk) > maxOrderList {
+			chunk = chunk[:maxOrderList]
+		}
+		valid = valid[len(chunk):]
+
+		orders := make([]map[string]interface{}, len(chunk))
+		for i, index := range chunk {
+			orders[i] = reqs[index].params()
+		}
+		_, results, err := client.createOrderList(ctx, LIST, orders)
+		if err != nil {
+			for _, index := range chunk {
+				output[index].Err = err
+			}
+			continue
+		}
+		for i, index := range chunk {
+			if i < len(results) {
+				output[index] = results[i]
+			} else {
+				output[index].Err = fmt.Errorf("no result for order %d", i)
+			}
+		}
+	}
+
+	failed := 0
+	for _, result := range output {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return output, fmt.Errorf("%d of %d orders failed", failed, len(output))
+	}
+
+	return output, nil
+}
+
+// createOrderList submits private/create-order-list and returns the list id (OCO only) and one OrderResult per order,
+// in the same order (LIST only).
+func (client *Client) createOrderList(ctx context.Context, contingency ContingencyType, orders []map[string]interface{}) (string, []OrderResult, error) {
+	params := make(map[string]interface{})
+	params["contingency_type"] = contingency
+	params["order_list"] = orders
+	raw, err := client.post(ctx, "private/create-order-list", params, 150)
+	if err != nil {
+		return "", nil, err
+	}
+	type Result struct {
+		ListId     json.Number `json:"list_id"`
+		ResultList []struct {
+			Index   int    `json:"index"`
+			Code    int64  `json:"code"`
+			Message string `json:"message"`
+			OrderId string `json:"order_id"`
+		} `json:"result_list"`
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return "", nil, err
+	}
+	if contingency != LIST {
+		return result.ListId.String(), nil, nil
+	}
+	output := make([]OrderResult, len(orders))
+	for i := range output {
+		output[i].Err = fmt.Errorf("no result for order %d", i)
+	}
+	for _, leg := range result.ResultList {
+		if leg.Index < 0 || leg.Index >= len(output) {
+			continue
+		}
+		if leg.Code != 0 {
+			output[leg.Index] = OrderResult{Err: &APIError{
+				Code:    leg.Code,
+				Message: leg.Message,
+				Method:  "POST",
+				Path:    "private/create-order-list",
+			}}
+		} else {
+			output[leg.Index] = OrderResult{OrderId: leg.OrderId}
+		}
+	}
+	return "", output, nil
+}
+
+// CreateOCO submits a one-cancels-other pair: when one of the orders executes, the exchange cancels the other one. Both
+// orders are checked against the trading rules of their instrument (see CheckOrder) first.
+func (client *Client) CreateOCO(stopLoss, takeProfit *OrderRequest) (*OrderList, error) {
+	return client.CreateOCOContext(context.Background(), stopLoss, takeProfit)
+}
+
+func (client *Client) CreateOCOContext(ctx context.Context, stopLoss, takeProfit *OrderRequest) (*OrderList, error) {
+	if stopLoss == nil || takeProfit == nil {
+		return nil, fmt.Errorf("%w: OCO requires a STOP_LOSS and a TAKE_PROFIT_LIMIT order", ErrInvalidOrder)
+	}
+	list := &OrderList{
+		ContingencyType: OCO,
+		Symbol:          stopLoss.Symbol,
+		Orders:          []OrderRequest{*stopLoss, *takeProfit},
+	}
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	for i := range list.Orders {
+		if err := client.CheckOrderContext(ctx, &list.Orders[i]); err != nil {
+			return nil, err
+		}
+	}
+	orders := make([]map[string]interface{}, len(list.Orders))
+	for i := range list.Orders {
+		orders[i] = list.Orders[i].params()
+	}
+	listId, _, err := client.createOrderList(ctx, OCO, orders)
+	if err != nil {
+		return nil, err
+	}
+	list.ListId = listId
+	return list, nil
+}
+
+// CancelOrderList cancels an OCO order list. It returns an error that wraps ErrInvalidOrder if list is nil, or has no
+// list id or symbol.
+func (client *Client) CancelOrderList(list *OrderList) error {
+	return client.CancelOrderListContext(context.Background(), list)
+}
+
+func (client *Client) CancelOrderListContext(ctx context.Context, list *OrderList) error {
+	if list == nil || list.ListId == "" || list.Symbol == "" {
+		return fmt.Errorf("%w: cancelling an order list requires its list id and symbol", ErrInvalidOrder)
+	}
+	params := make(map[string]interface{})
+	params["contingency_type"] = list.ContingencyType
+	params["list_id"] = list.ListId
+	params["instrument_name"] = list.Symbol
+	_, err := client.post(ctx, "private/cancel-order-list", params, 150)
+	return err
 }
 
 func (client *Client) GetOrder(symbol, orderId string) (*Order, error) {
+	return client.GetOrderContext(context.Background(), symbol, orderId)
+}
+
+func (client *Client) GetOrderContext(ctx context.Context, symbol, orderId string) (*Order, error) {
 	params := make(map[string]interface{})
 	params["instrument_name"] = symbol
 	params["order_id"] = orderId
-	raw, err := client.post("private/get-order-detail", params, 300)
+	raw, err := client.post(ctx, "private/get-order-detail", params, 300)
 	if err != nil {
 		return nil, err
 	}
@@ -521,16 +1033,78 @@ func (client *Client) GetOrder(symbol, orderId string) (*Order, error) {
 }
 
 func (client *Client) CancelOrder(symbol, orderId string) error {
+	return client.CancelOrderContext(context.Background(), symbol, orderId)
+}
+
+func (client *Client) CancelOrderContext(ctx context.Context, symbol, orderId string) error {
 	params := make(map[string]interface{})
 	params["instrument_name"] = symbol
 	params["order_id"] = orderId
-	_, err := client.post("private/cancel-order", params, 150)
+	_, err := client.post(ctx, "private/cancel-order", params, 150)
+	return err
+}
+
+// CancelAllOrders cancels all open orders for symbol.
+func (client *Client) CancelAllOrders(symbol string) error {
+	return client.CancelAllOrdersContext(context.Background(), symbol)
+}
+
+func (client *Client) CancelAllOrdersContext(ctx context.Context, symbol string) error {
+	params := make(map[string]interface{})
+	params["instrument_name"] = symbol
+	_, err := client.post(ctx, "private/cancel-all-orders", params, 150)
 	return err
 }
 
+// CancelResult summarizes the outcome of CancelAllEverywhere for one instrument.
+type CancelResult struct {
+	Symbol string // e.g. ETH_CRO, BTC_USDT
+	Orders int    // the number of open orders we asked the exchange to cancel
+	Err    error  // nil if the exchange accepted the request
+}
+
+// CancelAllEverywhere cancels all open orders for every instrument that has any. The error is non-nil only if we cannot
+// find out which instruments have open orders; check every CancelResult for per-instrument failures.
+func (client *Client) CancelAllEverywhere() ([]CancelResult, error) {
+	return client.CancelAllEverywhereContext(context.Background())
+}
+
+func (client *Client) CancelAllEverywhereContext(ctx context.Context) ([]CancelResult, error) {
+	orders, err := client.OpenOrdersContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	count := make(map[string]int)
+	for _, order := range orders {
+		count[order.Symbol]++
+	}
+
+	var result []CancelResult
+	for symbol, orders := range count {
+		result = append(result, CancelResult{
+			Symbol: symbol,
+			Orders: orders,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Symbol < result[j].Symbol
+	})
+
+	for i := range result {
+		result[i].Err = client.CancelAllOrdersContext(ctx, result[i].Symbol)
+	}
+
+	return result, nil
+}
+
 func (client *Client) OpenOrders(symbol string) ([]Order, error) {
+	return client.OpenOrdersContext(context.Background(), symbol)
+}
+
+func (client *Client) OpenOrdersContext(ctx context.Context, symbol string) ([]Order, error) {
 	call := func(params map[string]interface{}) (int, []Order, error) {
-		raw, err := client.post("private/get-open-orders", params, 30)
+		raw, err := client.post(ctx, "private/get-open-orders", params, 30)
 		if err != nil {
 			return 0, nil, err
 		}
@@ -568,9 +1142,69 @@ func (client *Client) OpenOrders(symbol string) ([]Order, error) {
 	return result, nil
 }
 
+// OrderHistory returns the orders created between start and end, oldest first. The exchange returns at most 24 hours of
+// history per request, so OrderHistory splits longer ranges into as many requests as it takes.
+func (client *Client) OrderHistory(symbol string, start, end time.Time) ([]Order, error) {
+	return client.OrderHistoryContext(context.Background(), symbol, start, end)
+}
+
+func (client *Client) OrderHistoryContext(ctx context.Context, symbol string, start, end time.Time) ([]Order, error) {
+	if err := checkRange(start, end); err != nil {
+		return nil, err
+	}
+
+	call := func(params map[string]interface{}) ([]Order, error) {
+		raw, err := client.post(ctx, "private/get-order-history", params, 1)
+		if err != nil {
+			return nil, err
+		}
+		type Result struct {
+			OrderList []Order `json:"order_list"`
+		}
+		var result Result
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		return result.OrderList, nil
+	}
+
+	var (
+		seen   = make(map[string]bool)
+		result []Order
+	)
+
+	if err := windows(start, end, maxHistoryWindow, func(from, to time.Time) error {
+		return paginate(historyParams(symbol, from, to), func(params map[string]interface{}) (int, error) {
+			orders, err := call(params)
+			if err != nil {
+				return 0, err
+			}
+			for _, order := range orders {
+				if !seen[order.OrderId] {
+					seen[order.OrderId] = true
+					result = append(result, order)
+				}
+			}
+			return len(orders), nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt < result[j].CreatedAt
+	})
+
+	return result, nil
+}
+
 func (client *Client) MyTrades(symbol string) ([]Trade, error) {
+	return client.MyTradesContext(context.Background(), symbol)
+}
+
+func (client *Client) MyTradesContext(ctx context.Context, symbol string) ([]Trade, error) {
 	call := func(params map[string]interface{}) (int, []Trade, error) {
-		raw, err := client.post("private/get-trades", params, 1)
+		raw, err := client.post(ctx, "private/get-trades", params, 1)
 		if err != nil {
 			return 0, nil, err
 		}
@@ -607,3 +1241,94 @@ func (client *Client) MyTrades(symbol string) ([]Trade, error) {
 
 	return result, nil
 }
+
+// MyTradesBetween returns the trades executed between start and end, oldest first. The exchange returns at most 24 hours
+// of history per request, so MyTradesBetween splits longer ranges into as many requests as it takes.
+func (client *Client) MyTradesBetween(symbol string, start, end time.Time) ([]Trade, error) {
+	return client.MyTradesBetweenContext(context.Background(), symbol, start, end)
+}
+
+func (client *Client) MyTradesBetweenContext(ctx context.Context, symbol string, start, end time.Time) ([]Trade, error) {
+	if err := checkRange(start, end); err != nil {
+		return nil, err
+	}
+
+	call := func(params map[string]interface{}) ([]Trade, error) {
+		raw, err := client.post(ctx, "private/get-trades", params, 1)
+		if err != nil {
+			return nil, err
+		}
+		type Result struct {
+			TradeList []Trade `json:"trade_list"`
+		}
+		var result Result
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		return result.TradeList, nil
+	}
+
+	var (
+		seen   = make(map[string]bool)
+		result []Trade
+	)
+
+	if err := windows(start, end, maxHistoryWindow, func(from, to time.Time) error {
+		return paginate(historyParams(symbol, from, to), func(params map[string]interface{}) (int, error) {
+			trades, err := call(params)
+			if err != nil {
+				return 0, err
+			}
+			for _, trade := range trades {
+				if !seen[trade.TradeId] {
+					seen[trade.TradeId] = true
+					result = append(result, trade)
+				}
+			}
+			return len(trades), nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].before(&result[j])
+	})
+
+	return result, nil
+}
+
+// MyTradesAfter returns the trades executed after cursor, oldest first, and the cursor to pass next time. Use
+// MyTradesBetween for your initial sync, and NewTradeCursor with the last trade it returned to start from.
+func (client *Client) MyTradesAfter(symbol string, cursor TradeCursor) ([]Trade, TradeCursor, error) {
+	return client.MyTradesAfterContext(context.Background(), symbol, cursor)
+}
+
+func (client *Client) MyTradesAfterContext(ctx context.Context, symbol string, cursor TradeCursor) ([]Trade, TradeCursor, error) {
+	if cursor.CreatedAt == 0 {
+		return nil, cursor, errors.New("empty trade cursor")
+	}
+
+	end := time.Now()
+	if !cursor.time().Before(end) {
+		// the clock of the exchange is ahead of ours, so look a little past the cursor rather than nowhere at all
+		end = cursor.time().Add(time.Second)
+	}
+
+	trades, err := client.MyTradesBetweenContext(ctx, symbol, cursor.time(), end)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	var result []Trade
+	for i := range trades {
+		if cursor.before(&trades[i]) {
+			result = append(result, trades[i])
+		}
+	}
+	if len(result) > 0 {
+		cursor = NewTradeCursor(&result[len(result)-1])
+	}
+
+	return result, cursor, nil
+}
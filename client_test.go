@@ -63,3 +63,48 @@ func TestOrderBook(t *testing.T) {
 
 	t.Logf("%+v", book)
 }
+
+func TestCandlesticks(t *testing.T) {
+	client := New("", "")
+
+	candles, err := client.Candlesticks("ETH_BTC", HOUR_1)
+	if err != nil {
+		t.Errorf("Candlesticks(\"ETH_BTC\", HOUR_1) failed: %v", err)
+	}
+
+	if len(candles) == 0 {
+		t.Error("Candlesticks(\"ETH_BTC\", HOUR_1) returned an empty response")
+	}
+
+	t.Logf("%+v", candles)
+}
+
+func TestPublicTrades(t *testing.T) {
+	client := New("", "")
+
+	trades, err := client.PublicTrades("ETH_BTC")
+	if err != nil {
+		t.Errorf("PublicTrades(\"ETH_BTC\") failed: %v", err)
+	}
+
+	if len(trades) == 0 {
+		t.Error("PublicTrades(\"ETH_BTC\") returned an empty response")
+	}
+
+	t.Logf("%+v", trades)
+}
+
+func TestOrderBookDepth(t *testing.T) {
+	client := New("", "")
+
+	book, err := client.OrderBookDepth("ETH_BTC", 10)
+	if err != nil {
+		t.Fatalf("OrderBookDepth(\"ETH_BTC\", 10) failed: %v", err)
+	}
+
+	if len(book.Bids) > 10 || len(book.Asks) > 10 {
+		t.Error("OrderBookDepth(\"ETH_BTC\", 10) returned more than 10 entries")
+	}
+
+	t.Logf("%+v", book)
+}
@@ -0,0 +1,16 @@
+package crypto
+
+import (
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact decimal number. Prices, quantities and balances decode from JSON strings as well as JSON numbers
+// without losing precision, see https://pkg.go.dev/github.com/shopspring/decimal
+type Decimal = decimal.Decimal
+
+// number returns d as a JSON number, so the exchange receives (and we sign) exactly the digits in d.
+func number(d Decimal) json.Number {
+	return json.Number(d.String())
+}
@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestDecimalParams(t *testing.T) {
+	req := OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("0.1").Add(dec("0.2")), Price: dec("123456789.123456789")}
+	params := req.params()
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if string(decoded["quantity"]) != "0.3" || string(decoded["price"]) != "123456789.123456789" {
+		t.Errorf("unexpected request body: %s", body)
+	}
+
+	if s := paramString(params["price"]); s != "123456789.123456789" {
+		t.Errorf("paramString() returned %s, expected 123456789.123456789", s)
+	}
+	if s := paramString(dec("0.00000001")); s != "0.00000001" {
+		t.Errorf("paramString() returned %s, expected 0.00000001", s)
+	}
+}
+
+func TestDecimalAccount(t *testing.T) {
+	var account Account
+	if err := json.Unmarshal([]byte(`{"balance":"0.30000000000000004","available":0.1,"order":null,"stake":0,"currency":"CRO"}`), &account); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if account.Balance.String() != "0.30000000000000004" || !account.Available.Equal(dec("0.1")) || !account.Order.IsZero() {
+		t.Errorf("unexpected account: %+v", account)
+	}
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type DepositStatus string
+
+const (
+	DEPOSIT_STATUS_NOT_ARRIVED DepositStatus = "0"
+	DEPOSIT_STATUS_ARRIVED     DepositStatus = "1"
+	DEPOSIT_STATUS_FAILED      DepositStatus = "2"
+	DEPOSIT_STATUS_PENDING     DepositStatus = "3"
+)
+
+type DepositAddress struct {
+	Id        string // deposit address ID
+	Currency  string // e.g. CRO
+	Network   string // the network (or chain) to deposit on, e.g. ETH or CRO
+	Address   string // the address to deposit to
+	Tag       string // the tag (or memo) to include with the deposit, if any
+	Active    bool   // false if the exchange no longer accepts deposits to this address
+	CreatedAt int64  // creation time
+}
+
+func (address *DepositAddress) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Id         string `json:"id"`
+		Currency   string `json:"currency"`
+		Network    string `json:"network"`
+		Address    string `json:"address"`
+		Status     string `json:"status"` // 0 - inactive, 1 - active
+		CreateTime int64  `json:"create_time"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	address.Id = raw.Id
+	address.Currency = raw.Currency
+	address.Network = raw.Network
+	address.Active = raw.Status == "1"
+	address.CreatedAt = raw.CreateTime
+	// the exchange appends the tag (or memo) to the address, e.g. rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh?dt=12345
+	address.Address, address.Tag = raw.Address, ""
+	if i := strings.Index(raw.Address, "?"); i >= 0 {
+		address.Address = raw.Address[:i]
+		if j := strings.Index(raw.Address[i:], "="); j >= 0 {
+			address.Tag = raw.Address[i+j+1:]
+		}
+	}
+	return nil
+}
+
+type Deposit struct {
+	Id        string        `json:"id"`          // deposit ID
+	Currency  string        `json:"currency"`    // e.g. CRO
+	Amount    Decimal       `json:"amount"`      // deposited amount
+	Fee       Decimal       `json:"fee"`         // deposit fee
+	Address   string        `json:"address"`     // the address the deposit was made to
+	Status    DepositStatus `json:"status"`      // 0 - not arrived, 1 - arrived, 2 - failed, 3 - pending
+	CreatedAt int64         `json:"create_time"` // creation time
+	UpdatedAt int64         `json:"update_time"` // last update time
+}
+
+func (deposit *Deposit) GetCreatedAt() time.Time {
+	if deposit.CreatedAt > 0 {
+		return time.Unix(deposit.CreatedAt/1000, 0)
+	}
+	return time.Time{}
+}
+
+func (deposit *Deposit) GetUpdatedAt() time.Time {
+	if deposit.UpdatedAt > 0 {
+		return time.Unix(deposit.UpdatedAt/1000, 0)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDepositAddresses(t *testing.T) {
+	server := newTestServer(t, map[string]testHandler{"private/get-deposit-address": func(params map[string]interface{}) interface{} {
+		if params["currency"] != "XRP" {
+			t.Errorf("unexpected currency: %v", params["currency"])
+		}
+		return map[string]interface{}{
+			"deposit_address_list": []map[string]interface{}{
+				{"currency": "XRP", "create_time": 1615886328000, "id": "1", "address": "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh?dt=12345", "status": "1", "network": "XRP"},
+				{"currency": "XRP", "create_time": 1615886329000, "id": "2", "address": "rLHzPsX6oXkzU2qL12kHCH8G8cnZv1rBJh", "status": "0", "network": "BSC"},
+			},
+		}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	addresses, err := client.DepositAddresses("XRP")
+	if err != nil {
+		t.Fatalf("DepositAddresses() failed: %v", err)
+	}
+	if len(addresses) != 2 {
+		t.Fatalf("DepositAddresses() returned %d addresses, expected 2", len(addresses))
+	}
+	if addresses[0].Address != "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh" || addresses[0].Tag != "12345" || !addresses[0].Active || addresses[0].Network != "XRP" {
+		t.Errorf("unexpected address: %+v", addresses[0])
+	}
+	if addresses[1].Address != "rLHzPsX6oXkzU2qL12kHCH8G8cnZv1rBJh" || addresses[1].Tag != "" || addresses[1].Active {
+		t.Errorf("unexpected address: %+v", addresses[1])
+	}
+}
+
+func TestDepositHistory(t *testing.T) {
+	var (
+		end   = time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+		start = end.Add(-72 * time.Hour)
+	)
+
+	server := newTestServer(t, map[string]testHandler{"private/get-deposit-history": func(params map[string]interface{}) interface{} {
+		if params["status"] != string(DEPOSIT_STATUS_ARRIVED) || params["currency"] != "CRO" {
+			t.Errorf("unexpected params: %v", params)
+		}
+		if int64(params["start_ts"].(float64)) != timestamp(start) || int64(params["end_ts"].(float64)) != timestamp(end) {
+			t.Errorf("unexpected time range: %v", params)
+		}
+		// a full page first, then a page with fewer deposits (and the last one of the previous page)
+		var (
+			page     = 0
+			deposits []map[string]interface{}
+		)
+		if v, ok := params["page"]; ok {
+			page = int(v.(float64))
+		}
+		if page == 0 {
+			for i := 0; i < maxPageSize; i++ {
+				deposits = append(deposits, map[string]interface{}{"id": fmt.Sprint(i), "currency": "CRO", "amount": "1.5", "status": "1", "create_time": timestamp(end) - int64(i)})
+			}
+		} else {
+			deposits = append(deposits,
+				map[string]interface{}{"id": fmt.Sprint(maxPageSize - 1), "currency": "CRO", "amount": "1.5", "status": "1", "create_time": timestamp(end) - int64(maxPageSize-1)},
+				map[string]interface{}{"id": "oldest", "currency": "CRO", "amount": 2, "fee": 0.1, "status": "1", "create_time": timestamp(start)},
+			)
+		}
+		return map[string]interface{}{"deposit_list": deposits}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	deposits, err := client.DepositHistory("CRO", start, end, DEPOSIT_STATUS_ARRIVED)
+	if err != nil {
+		t.Fatalf("DepositHistory() failed: %v", err)
+	}
+	if len(deposits) != maxPageSize+1 {
+		t.Fatalf("DepositHistory() returned %d deposits, expected %d", len(deposits), maxPageSize+1)
+	}
+	if deposits[0].Id != "oldest" || !deposits[0].Amount.Equal(dec("2")) || !deposits[0].Fee.Equal(dec("0.1")) || deposits[0].Status != DEPOSIT_STATUS_ARRIVED {
+		t.Errorf("unexpected deposit: %+v", deposits[0])
+	}
+	if deposits[len(deposits)-1].Id != "0" {
+		t.Errorf("DepositHistory() did not sort the deposits, the last one is %s", deposits[len(deposits)-1].Id)
+	}
+}
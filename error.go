@@ -0,0 +1,162 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// exchange response codes, see https://exchange-docs.crypto.com/spot/index.html#response-and-reason-codes
+const (
+	CODE_SYS_ERROR                      int64 = 10001
+	CODE_UNAUTHORIZED                   int64 = 10002
+	CODE_IP_ILLEGAL                     int64 = 10003
+	CODE_BAD_REQUEST                    int64 = 10004
+	CODE_USER_TIER_INVALID              int64 = 10005
+	CODE_TOO_MANY_REQUESTS              int64 = 10006
+	CODE_INVALID_NONCE                  int64 = 10007
+	CODE_METHOD_NOT_FOUND               int64 = 10008
+	CODE_INVALID_DATE_RANGE             int64 = 10009
+	CODE_DUPLICATE_RECORD               int64 = 20001
+	CODE_NEGATIVE_BALANCE               int64 = 20002
+	CODE_SYMBOL_NOT_FOUND               int64 = 30003
+	CODE_SIDE_NOT_SUPPORTED             int64 = 30004
+	CODE_ORDERTYPE_NOT_SUPPORTED        int64 = 30005
+	CODE_MIN_PRICE_VIOLATED             int64 = 30006
+	CODE_MAX_PRICE_VIOLATED             int64 = 30007
+	CODE_MIN_QUANTITY_VIOLATED          int64 = 30008
+	CODE_MAX_QUANTITY_VIOLATED          int64 = 30009
+	CODE_MISSING_ARGUMENT               int64 = 30010
+	CODE_INVALID_PRICE_PRECISION        int64 = 30013
+	CODE_INVALID_QUANTITY_PRECISION     int64 = 30014
+	CODE_MIN_NOTIONAL_VIOLATED          int64 = 30016
+	CODE_MAX_NOTIONAL_VIOLATED          int64 = 30017
+	CODE_MIN_AMOUNT_VIOLATED            int64 = 30023
+	CODE_MAX_AMOUNT_VIOLATED            int64 = 30024
+	CODE_AMOUNT_PRECISION_OVERFLOW      int64 = 30025
+	CODE_INVALID_ORDERID                int64 = 212
+	CODE_INSUFFICIENT_AVAILABLE_BALANCE int64 = 306
+)
+
+// APIError is returned by every Client method when the exchange rejects a request.
+type APIError struct {
+	StatusCode int           // HTTP status code, e.g. 400
+	Code       int64         // exchange response code, e.g. 10007 (INVALID_NONCE), or zero if the exchange did not return one
+	Message    string        // exchange message or details, or the HTTP status if the exchange did not return one
+	Method     string        // GET or POST
+	Path       string        // e.g. private/create-order
+	RequestId  int64         // the id of the request this is a response to
+	RetryAfter time.Duration // value of the Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s %s", e.Method, e.Path, e.Message)
+}
+
+func hasCode(err error, codes ...int64) bool {
+	var e *APIError
+	if errors.As(err, &e) {
+		for _, code := range codes {
+			if e.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsInsufficientBalance returns true if err indicates your available balance is too low for the request.
+func IsInsufficientBalance(err error) bool {
+	return hasCode(err, CODE_NEGATIVE_BALANCE, CODE_INSUFFICIENT_AVAILABLE_BALANCE)
+}
+
+// IsInvalidNonce returns true if err indicates the nonce was rejected, typically because your clock is out of sync.
+func IsInvalidNonce(err error) bool {
+	return hasCode(err, CODE_INVALID_NONCE)
+}
+
+// IsRateLimited returns true if err indicates you are exceeding the rate limits.
+func IsRateLimited(err error) bool {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == CODE_TOO_MANY_REQUESTS
+	}
+	return false
+}
+
+// IsOrderNotFound returns true if err indicates the order does not exist.
+func IsOrderNotFound(err error) bool {
+	return hasCode(err, CODE_INVALID_ORDERID)
+}
+
+// checkResponse returns an *APIError if the response indicates failure, or nil otherwise.
+func checkResponse(method, path string, response *http.Response, body []byte) error {
+	var status struct {
+		Id      int64       `json:"id"`
+		Code    interface{} `json:"code"`
+		Message interface{} `json:"message"`
+		Details interface{} `json:"details"`
+	}
+	if json.Unmarshal(body, &status) == nil && status.Code != nil {
+		code := func() int64 {
+			switch v := status.Code.(type) {
+			case float64:
+				return int64(v)
+			case string:
+				i, _ := strconv.ParseInt(v, 10, 64)
+				return i
+			}
+			return 0
+		}()
+		if code != 0 {
+			return &APIError{
+				StatusCode: response.StatusCode,
+				Code:       code,
+				Message: func() string {
+					if status.Details != nil {
+						return fmt.Sprintf("%v", status.Details)
+					} else if status.Message != nil {
+						return fmt.Sprintf("%v", status.Message)
+					} else {
+						return fmt.Sprintf("%v", status.Code)
+					}
+				}(),
+				Method:     method,
+				Path:       path,
+				RequestId:  status.Id,
+				RetryAfter: retryAfter(response),
+			}
+		}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &APIError{
+			StatusCode: response.StatusCode,
+			Message:    response.Status,
+			Method:     method,
+			Path:       path,
+			RequestId:  status.Id,
+			RetryAfter: retryAfter(response),
+		}
+	}
+
+	return nil
+}
+
+// retryAfter parses the Retry-After header, that is either a number of seconds or a HTTP date.
+func retryAfter(response *http.Response) time.Duration {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	response := &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+
+	err := checkResponse("POST", "private/create-order", response, []byte(`{"id":11,"method":"private/create-order","code":10007,"message":"INVALID_NONCE"}`))
+	if !IsInvalidNonce(err) {
+		t.Errorf("IsInvalidNonce(%v) returned false", err)
+	}
+	if IsInsufficientBalance(fmt.Errorf("wrapped: %w", err)) {
+		t.Errorf("IsInsufficientBalance(%v) returned true", err)
+	}
+	if err.Error() != "POST private/create-order INVALID_NONCE" {
+		t.Errorf("Error() returned %q", err.Error())
+	}
+	if err.(*APIError).RequestId != 11 {
+		t.Errorf("RequestId is %d, expected 11", err.(*APIError).RequestId)
+	}
+
+	response = &http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}
+	if err := checkResponse("GET", "public/get-book", response, nil); !IsRateLimited(err) {
+		t.Errorf("IsRateLimited(%v) returned false", err)
+	}
+
+	response = &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}
+	if err := checkResponse("GET", "public/get-book", response, []byte(`{"id":-1,"code":0,"result":{}}`)); err != nil {
+		t.Errorf("checkResponse() returned %v", err)
+	}
+}
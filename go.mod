@@ -1,3 +1,8 @@
 module github.com/svanas/go-crypto-dot-com
 
 go 1.16
+
+require (
+	github.com/gorilla/websocket v1.5.0
+	github.com/shopspring/decimal v1.3.1
+)
@@ -0,0 +1,145 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testHandler returns the result of a request, given its parameters.
+type testHandler func(params map[string]interface{}) interface{}
+
+// newTestServer returns a server that answers every request with the result of the handler for its method, e.g.
+// private/get-order-history. The handler for "" answers every method without a handler of its own.
+func newTestServer(t *testing.T, handlers map[string]testHandler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := Request{
+			Method: strings.TrimPrefix(r.URL.Path, "/"),
+			Params: make(map[string]interface{}),
+		}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("Decode() failed: %v", err)
+				return
+			}
+		} else {
+			for key := range r.URL.Query() {
+				request.Params[key] = r.URL.Query().Get(key)
+			}
+		}
+		fn, ok := handlers[request.Method]
+		if !ok {
+			if fn, ok = handlers[""]; !ok {
+				t.Errorf("unexpected method: %s", request.Method)
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     request.Id,
+			"method": request.Method,
+			"code":   0,
+			"result": fn(request.Params),
+		})
+	}))
+}
+
+// newTestClient returns a client that sends its requests to server, without rate limiting.
+func newTestClient(server *httptest.Server) *Client {
+	client := New("", "")
+	client.URL = server.URL + "/"
+	client.RateLimiter = nil
+	return client
+}
+
+func TestOrderHistory(t *testing.T) {
+	var (
+		end   = time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+		start = end.Add(-60 * time.Hour)
+	)
+
+	server := newTestServer(t, map[string]testHandler{"private/get-order-history": func(params map[string]interface{}) interface{} {
+		from, to := int64(params["start_ts"].(float64)), int64(params["end_ts"].(float64))
+		if to-from > int64(maxHistoryWindow/time.Millisecond) {
+			t.Errorf("window %d-%d exceeds 24 hours", from, to)
+		}
+		// one order per window, plus the same order in every window
+		return map[string]interface{}{
+			"order_list": []map[string]interface{}{
+				{"order_id": fmt.Sprint(from), "create_time": from},
+				{"order_id": "duplicate", "create_time": timestamp(start)},
+			},
+		}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	orders, err := client.OrderHistory("ETH_CRO", start, end)
+	if err != nil {
+		t.Fatalf("OrderHistory() failed: %v", err)
+	}
+
+	if len(orders) != 4 {
+		t.Fatalf("OrderHistory() returned %d orders, expected 4", len(orders))
+	}
+	for i := 1; i < len(orders); i++ {
+		if orders[i].CreatedAt < orders[i-1].CreatedAt {
+			t.Errorf("OrderHistory() is not sorted by creation time: %+v", orders)
+		}
+	}
+
+	if _, err := client.OrderHistory("ETH_CRO", time.Time{}, end); err == nil {
+		t.Error("OrderHistory() did not return an error for a zero start time")
+	}
+	if _, err := client.OrderHistory("ETH_CRO", end, start); err == nil {
+		t.Error("OrderHistory() did not return an error for a start time after the end time")
+	}
+}
+
+func TestMyTradesAfter(t *testing.T) {
+	now := timestamp(time.Now())
+
+	server := newTestServer(t, map[string]testHandler{"private/get-trades": func(params map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"trade_list": []map[string]interface{}{
+				{"trade_id": "11", "create_time": now - 1000},
+				{"trade_id": "9", "create_time": now - 2000},
+				{"trade_id": "10", "create_time": now - 2000},
+				{"trade_id": "8", "create_time": now - 3000},
+			},
+		}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	trades, cursor, err := client.MyTradesAfter("ETH_CRO", TradeCursor{TradeId: "9", CreatedAt: now - 2000})
+	if err != nil {
+		t.Fatalf("MyTradesAfter() failed: %v", err)
+	}
+
+	if len(trades) != 2 || trades[0].TradeId != "10" || trades[1].TradeId != "11" {
+		t.Errorf("MyTradesAfter() returned %+v, expected trades 10 and 11", trades)
+	}
+	if cursor.TradeId != "11" || cursor.CreatedAt != now-1000 {
+		t.Errorf("MyTradesAfter() returned cursor %+v, expected trade 11", cursor)
+	}
+
+	if _, _, err := client.MyTradesAfter("ETH_CRO", TradeCursor{}); err == nil {
+		t.Error("MyTradesAfter() did not return an error for an empty cursor")
+	}
+	if trades, _, err := client.MyTradesAfter("ETH_CRO", TradeCursor{TradeId: "12", CreatedAt: now + 60000}); err != nil || len(trades) != 0 {
+		t.Errorf("MyTradesAfter() returned %+v, %v for a cursor ahead of the local clock", trades, err)
+	}
+
+	if _, err := client.MyTradesBetween("ETH_CRO", time.Time{}, time.Now()); err == nil {
+		t.Error("MyTradesBetween() did not return an error for a zero start time")
+	}
+	if _, err := client.MyTradesBetween("ETH_CRO", time.Now(), time.Now().Add(-time.Hour)); err == nil {
+		t.Error("MyTradesBetween() did not return an error for a start time after the end time")
+	}
+}
@@ -0,0 +1,233 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInstrumentNotFound is returned when the exchange does not list an instrument.
+var ErrInstrumentNotFound = errors.New("instrument not found")
+
+// Instruments caches the instruments the exchange lists, so their trading rules are loaded once per TTL. It is safe for
+// concurrent use.
+type Instruments struct {
+	TTL     time.Duration // how long until the cached instruments are reloaded in the background. zero means until Refresh is called
+	client  *Client
+	mutex   sync.Mutex
+	set     *instrumentSet  // nil until the first load succeeds
+	loading *instrumentLoad // nil unless a load is in progress
+	minimum map[string]Decimal
+}
+
+// instrumentSet is a snapshot of the instruments. It is never modified, a load replaces it.
+type instrumentSet struct {
+	symbols map[string]Symbol // instrument name -> instrument
+	pairs   map[string]string // BASE_QUOTE -> instrument name
+	loaded  time.Time
+}
+
+// instrumentLoad is a load in progress, shared by every caller that needs it. err is set before done is closed.
+type instrumentLoad struct {
+	done chan struct{}
+	err  error
+}
+
+func newInstruments(client *Client) *Instruments {
+	return &Instruments{
+		TTL:    time.Hour,
+		client: client,
+	}
+}
+
+// Refresh reloads the instruments from the exchange.
+func (instruments *Instruments) Refresh() error {
+	return instruments.RefreshContext(context.Background())
+}
+
+func (instruments *Instruments) RefreshContext(ctx context.Context) error {
+	_, err := instruments.snapshot(ctx, true)
+	return err
+}
+
+// snapshot returns the instruments, and loads them first if they have not been loaded yet or if force is true. Concurrent
+// callers share the same load, but each of them stops waiting for it when its ctx is done. Expired instruments are
+// reloaded in the background, callers keep getting them until the reload replaces them.
+func (instruments *Instruments) snapshot(ctx context.Context, force bool) (*instrumentSet, error) {
+	instruments.mutex.Lock()
+	set := instruments.set
+	if !force && set != nil {
+		if instruments.TTL > 0 && time.Since(set.loaded) > instruments.TTL {
+			instruments.start()
+		}
+		instruments.mutex.Unlock()
+		return set, nil
+	}
+	load := instruments.start()
+	instruments.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-load.done:
+	}
+	if load.err != nil {
+		return nil, load.err
+	}
+
+	instruments.mutex.Lock()
+	defer instruments.mutex.Unlock()
+	return instruments.set, nil
+}
+
+// start returns the load in progress, or starts a new one. The caller must hold the mutex.
+func (instruments *Instruments) start() *instrumentLoad {
+	if instruments.loading == nil {
+		instruments.loading = &instrumentLoad{done: make(chan struct{})}
+		go instruments.load(instruments.loading)
+	}
+	return instruments.loading
+}
+
+// load loads the instruments without holding the mutex. It does not stop when the caller that started it gives up,
+// because other callers may still be waiting for it.
+func (instruments *Instruments) load(load *instrumentLoad) {
+	set, err := func() (*instrumentSet, error) {
+		symbols, err := instruments.client.Symbols()
+		if err != nil {
+			return nil, err
+		}
+		set := &instrumentSet{
+			symbols: make(map[string]Symbol, len(symbols)),
+			pairs:   make(map[string]string, len(symbols)),
+			loaded:  time.Now(),
+		}
+		for _, symbol := range symbols {
+			set.symbols[symbol.Symbol] = symbol
+			set.pairs[MakeSymbol(symbol.BaseCurrency, symbol.QuoteCurrency)] = symbol.Symbol
+		}
+		return set, nil
+	}()
+
+	instruments.mutex.Lock()
+	if err == nil {
+		instruments.set = set
+	}
+	instruments.loading = nil
+	instruments.mutex.Unlock()
+
+	load.err = err
+	close(load.done)
+}
+
+// SetMinNotional sets the minimum notional (quantity * price, in the quote currency) of an instrument. The exchange does
+// not publish its minimums, so CheckOrder and NormalizeOrder only enforce the ones you set.
+func (instruments *Instruments) SetMinNotional(name string, min Decimal) {
+	instruments.mutex.Lock()
+	defer instruments.mutex.Unlock()
+	if instruments.minimum == nil {
+		instruments.minimum = make(map[string]Decimal)
+	}
+	instruments.minimum[name] = min
+}
+
+// rules returns a copy of symbol with the trading rules you have set.
+func (instruments *Instruments) rules(symbol Symbol) *Symbol {
+	instruments.mutex.Lock()
+	defer instruments.mutex.Unlock()
+	symbol.MinNotional = instruments.minimum[symbol.Symbol]
+	return &symbol
+}
+
+// Get returns the instrument with the given name, e.g. BTC_USDT.
+func (instruments *Instruments) Get(name string) (*Symbol, error) {
+	return instruments.GetContext(context.Background(), name)
+}
+
+func (instruments *Instruments) GetContext(ctx context.Context, name string) (*Symbol, error) {
+	set, err := instruments.snapshot(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	symbol, ok := set.symbols[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInstrumentNotFound, name)
+	}
+	return instruments.rules(symbol), nil
+}
+
+// Find returns the instrument that trades base for quote, e.g. BTC and USDT.
+func (instruments *Instruments) Find(base, quote string) (*Symbol, error) {
+	return instruments.FindContext(context.Background(), base, quote)
+}
+
+func (instruments *Instruments) FindContext(ctx context.Context, base, quote string) (*Symbol, error) {
+	set, err := instruments.snapshot(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := set.pairs[MakeSymbol(base, quote)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInstrumentNotFound, MakeSymbol(base, quote))
+	}
+	return instruments.rules(set.symbols[name]), nil
+}
+
+// Markets returns every instrument that has currency as its base or quote currency, sorted by instrument name.
+func (instruments *Instruments) Markets(currency string) ([]Symbol, error) {
+	return instruments.MarketsContext(context.Background(), currency)
+}
+
+func (instruments *Instruments) MarketsContext(ctx context.Context, currency string) ([]Symbol, error) {
+	set, err := instruments.snapshot(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var output []Symbol
+	for _, symbol := range set.symbols {
+		if strings.EqualFold(symbol.BaseCurrency, currency) || strings.EqualFold(symbol.QuoteCurrency, currency) {
+			output = append(output, *instruments.rules(symbol))
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Symbol < output[j].Symbol
+	})
+	return output, nil
+}
+
+// CheckOrder returns an error that wraps ErrInvalidOrder if req is invalid, or breaks the trading rules of its instrument.
+func (client *Client) CheckOrder(req *OrderRequest) error {
+	return client.CheckOrderContext(context.Background(), req)
+}
+
+func (client *Client) CheckOrderContext(ctx context.Context, req *OrderRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	symbol, err := client.Instruments.GetContext(ctx, req.Symbol)
+	if err != nil {
+		return err
+	}
+	return symbol.Check(req)
+}
+
+// NormalizeOrder returns a copy of req with its prices and quantity rounded to the precision of its instrument, or an
+// error that wraps ErrInvalidOrder if the rounded order still breaks the trading rules.
+func (client *Client) NormalizeOrder(req OrderRequest, rounding Rounding) (*OrderRequest, error) {
+	return client.NormalizeOrderContext(context.Background(), req, rounding)
+}
+
+func (client *Client) NormalizeOrderContext(ctx context.Context, req OrderRequest, rounding Rounding) (*OrderRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	symbol, err := client.Instruments.GetContext(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	return symbol.Normalize(req, rounding)
+}
@@ -0,0 +1,239 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// instruments returns a handler for public/get-instruments that counts how often it is called. If block is not nil, it
+// does not answer until block is closed.
+func instruments(calls *int32, block <-chan struct{}) testHandler {
+	return func(params map[string]interface{}) interface{} {
+		atomic.AddInt32(calls, 1)
+		if block != nil {
+			<-block
+		}
+		return map[string]interface{}{
+			"instruments": []map[string]interface{}{
+				{"instrument_name": "ETH_CRO", "base_currency": "ETH", "quote_currency": "CRO", "price_decimals": 2, "quantity_decimals": 3, "min_quantity": "0.001", "max_quantity": "100"},
+				{"instrument_name": "CRO_USDT", "base_currency": "CRO", "quote_currency": "USDT", "price_decimals": 5, "quantity_decimals": 0, "min_quantity": "1", "max_quantity": "1000000"},
+			},
+		}
+	}
+}
+
+func TestSymbolRules(t *testing.T) {
+	symbol := Symbol{Symbol: "ETH_CRO", BaseCurrency: "ETH", QuoteCurrency: "CRO", PriceDecimals: 2, QuantityDecimals: 3, MinQuantity: dec("0.001"), MaxQuantity: dec("100"), MinNotional: dec("10")}
+
+	if price := symbol.RoundPrice(dec("123.456"), ROUND_DOWN); !price.Equal(dec("123.45")) {
+		t.Errorf("RoundPrice(ROUND_DOWN) returned %v, expected 123.45", price)
+	}
+	if price := symbol.RoundPrice(dec("123.451"), ROUND_UP); !price.Equal(dec("123.46")) {
+		t.Errorf("RoundPrice(ROUND_UP) returned %v, expected 123.46", price)
+	}
+	if quantity := symbol.RoundQuantity(dec("1.2345"), ROUND_NEAREST); !quantity.Equal(dec("1.235")) {
+		t.Errorf("RoundQuantity(ROUND_NEAREST) returned %v, expected 1.235", quantity)
+	}
+
+	invalid := []OrderRequest{
+		{Symbol: "ETH_USDT", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100.001")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1.0001"), Price: dec("100")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("101"), Price: dec("100")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("0.05"), Price: dec("100")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: MARKET, Notional: dec("5")},
+	}
+	for _, req := range invalid {
+		if err := symbol.Check(&req); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("Check(%+v) returned %v, expected %v", req, err, ErrInvalidOrder)
+		}
+	}
+
+	req, err := symbol.Normalize(OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1.23456"), Price: dec("100.009")}, ROUND_DOWN)
+	if err != nil {
+		t.Fatalf("Normalize() failed: %v", err)
+	}
+	if !req.Quantity.Equal(dec("1.234")) || !req.Price.Equal(dec("100")) {
+		t.Errorf("Normalize() returned %+v", req)
+	}
+	if _, err := symbol.Normalize(OrderRequest{Symbol: "ETH_CRO", Side: SELL, Type: MARKET, Quantity: dec("0.0004")}, ROUND_DOWN); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Normalize() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+}
+
+func TestInstruments(t *testing.T) {
+	var calls int32
+	server := newTestServer(t, map[string]testHandler{"public/get-instruments": instruments(&calls, nil)})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	symbol, err := client.Instruments.Get("ETH_CRO")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if symbol.QuantityDecimals != 3 || !symbol.MinNotional.IsZero() {
+		t.Errorf("unexpected instrument: %+v", symbol)
+	}
+	if err := client.CheckOrder(&OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: MARKET, Notional: dec("5")}); err != nil {
+		t.Errorf("CheckOrder() failed: %v", err)
+	}
+
+	client.Instruments.SetMinNotional("ETH_CRO", dec("10"))
+	if symbol, err := client.Instruments.Get("ETH_CRO"); err != nil || !symbol.MinNotional.Equal(dec("10")) {
+		t.Errorf("Get() returned %+v, %v after SetMinNotional()", symbol, err)
+	}
+	if err := client.CheckOrder(&OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: MARKET, Notional: dec("5")}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CheckOrder() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+	if _, err := client.NormalizeOrder(OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("0.05"), Price: dec("100.001")}, ROUND_DOWN); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("NormalizeOrder() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+	if _, err := client.Instruments.Get("BTC_CRO"); !errors.Is(err, ErrInstrumentNotFound) {
+		t.Errorf("Get() returned %v, expected %v", err, ErrInstrumentNotFound)
+	}
+
+	req, err := client.NormalizeOrder(OrderRequest{Symbol: "CRO_USDT", Side: SELL, Type: LIMIT, Quantity: dec("150.7"), Price: dec("0.123456")}, ROUND_UP)
+	if err != nil {
+		t.Fatalf("NormalizeOrder() failed: %v", err)
+	}
+	if !req.Quantity.Equal(dec("151")) || !req.Price.Equal(dec("0.12346")) {
+		t.Errorf("NormalizeOrder() returned %+v", req)
+	}
+	if err := client.CheckOrder(&OrderRequest{Symbol: "CRO_USDT", Side: SELL, Type: LIMIT, Quantity: dec("0.5"), Price: dec("0.1")}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CheckOrder() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+
+	if symbol, err := client.Instruments.Find("cro", "usdt"); err != nil || symbol.Symbol != "CRO_USDT" {
+		t.Errorf("Find(\"cro\", \"usdt\") returned %+v, %v", symbol, err)
+	}
+	if _, err := client.Instruments.Find("USDT", "CRO"); !errors.Is(err, ErrInstrumentNotFound) {
+		t.Errorf("Find(\"USDT\", \"CRO\") returned %v, expected %v", err, ErrInstrumentNotFound)
+	}
+	if markets, err := client.Instruments.Markets("CRO"); err != nil || len(markets) != 2 || markets[0].Symbol != "CRO_USDT" || markets[1].Symbol != "ETH_CRO" {
+		t.Errorf("Markets(\"CRO\") returned %+v, %v", markets, err)
+	}
+	if markets, err := client.Instruments.Markets("BTC"); err != nil || len(markets) != 0 {
+		t.Errorf("Markets(\"BTC\") returned %+v, %v", markets, err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("instruments were loaded %d times, expected once", n)
+	}
+
+	// expire the cache
+	client.Instruments.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, err := client.Instruments.Get("ETH_CRO"); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&calls) < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("instruments were loaded %d times, expected twice", n)
+	}
+}
+
+func TestInstrumentsExpired(t *testing.T) {
+	var (
+		calls int32
+		load  = instruments(&calls, nil)
+		block = make(chan struct{})
+	)
+	server := newTestServer(t, map[string]testHandler{"public/get-instruments": func(params map[string]interface{}) interface{} {
+		if atomic.LoadInt32(&calls) == 0 {
+			return load(params)
+		}
+		// every reload waits for block, and then fails
+		atomic.AddInt32(&calls, 1)
+		<-block
+		return "unavailable"
+	}})
+	defer server.Close()
+	defer close(block)
+
+	client := newTestClient(server)
+	if err := client.Instruments.Refresh(); err != nil {
+		t.Fatalf("Refresh() failed: %v", err)
+	}
+	client.Instruments.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+
+	// expired instruments are served while they reload, and a reload that fails does not replace them
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if symbol, err := client.Instruments.GetContext(ctx, "ETH_CRO"); err != nil || symbol.Symbol != "ETH_CRO" {
+		t.Fatalf("GetContext() returned %+v, %v while reloading", symbol, err)
+	}
+	block <- struct{}{}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		client.Instruments.mutex.Lock()
+		loading := client.Instruments.loading
+		client.Instruments.mutex.Unlock()
+		if loading == nil {
+			break
+		}
+	}
+	if err := client.CheckOrder(&OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100")}); err != nil {
+		t.Errorf("CheckOrder() failed after a failed reload: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n < 2 {
+		t.Errorf("instruments were loaded %d times, expected a reload", n)
+	}
+}
+
+func TestInstrumentsContext(t *testing.T) {
+	var (
+		calls int32
+		block = make(chan struct{})
+	)
+	server := newTestServer(t, map[string]testHandler{"public/get-instruments": instruments(&calls, block)})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	// a caller that gives up does not wait for the load
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Instruments.GetContext(ctx, "ETH_CRO"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetContext() returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	// every other caller shares the load that is still in progress
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Instruments.Get("ETH_CRO"); err != nil {
+				t.Errorf("Get() failed: %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(block)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("instruments were loaded %d times, expected once", n)
+	}
+}
+
+func TestParseSymbol(t *testing.T) {
+	for _, name := range []string{"BTC_USDT", "btc/usdt"} {
+		if base, quote, err := ParseSymbol(name); err != nil || base != "BTC" || quote != "USDT" {
+			t.Errorf("ParseSymbol(%q) returned %s, %s, %v", name, base, quote, err)
+		}
+	}
+	if _, _, err := ParseSymbol("BTCUSD-PERP"); err == nil {
+		t.Error("ParseSymbol(\"BTCUSD-PERP\") did not return an error")
+	}
+	if name := MakeSymbol("btc", "usdt"); name != "BTC_USDT" {
+		t.Errorf("MakeSymbol() returned %s, expected BTC_USDT", name)
+	}
+}
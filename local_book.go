@@ -0,0 +1,259 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrSequenceGap is returned when a streaming book update does not follow the previous one.
+var ErrSequenceGap = errors.New("order book sequence gap")
+
+// levels is a price-sorted slice of book entries: descending for bids, ascending for asks.
+type levels struct {
+	entries    []BookEntry
+	descending bool
+}
+
+// search returns the index where price is, or should be inserted.
+func (l *levels) search(price Decimal) int {
+	return sort.Search(len(l.entries), func(i int) bool {
+		if l.descending {
+			return l.entries[i].Price.LessThanOrEqual(price)
+		}
+		return l.entries[i].Price.GreaterThanOrEqual(price)
+	})
+}
+
+// set inserts, replaces or (if size is zero) removes a price level.
+func (l *levels) set(entry BookEntry) {
+	i := l.search(entry.Price)
+	found := i < len(l.entries) && l.entries[i].Price.Equal(entry.Price)
+	switch {
+	case entry.Size.IsZero():
+		if found {
+			l.entries = append(l.entries[:i], l.entries[i+1:]...)
+		}
+	case found:
+		l.entries[i] = entry
+	default:
+		l.entries = append(l.entries, BookEntry{})
+		copy(l.entries[i+1:], l.entries[i:])
+		l.entries[i] = entry
+	}
+}
+
+func (l *levels) reset(entries []BookEntry) {
+	l.entries = l.entries[:0]
+	for _, entry := range entries {
+		l.set(entry)
+	}
+}
+
+// LocalBook is an order book that is kept up to date from streaming snapshots and deltas. It is safe for concurrent use.
+type LocalBook struct {
+	Symbol    string
+	mutex     sync.RWMutex
+	client    *Client
+	depth     int
+	bids      levels
+	asks      levels
+	updateId  int64
+	timestamp int64
+}
+
+// NewLocalBook returns an empty book for symbol. The client is used to resync the book from the REST API, at the given depth.
+func (client *Client) NewLocalBook(symbol string, depth int) *LocalBook {
+	return &LocalBook{
+		Symbol: symbol,
+		client: client,
+		depth:  depth,
+		bids:   levels{descending: true},
+		asks:   levels{descending: false},
+	}
+}
+
+// Apply ingests a snapshot or a delta. Updates without a previous sequence number are snapshots that replace the book.
+// Deltas must follow the previous update, or Apply returns ErrSequenceGap and leaves the book as it was.
+func (lb *LocalBook) Apply(book *OrderBook) error {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	if book.PrevUpdateId == 0 {
+		lb.bids.reset(book.Bids)
+		lb.asks.reset(book.Asks)
+	} else {
+		if book.PrevUpdateId != lb.updateId {
+			return fmt.Errorf("%w: expected %d, got %d", ErrSequenceGap, lb.updateId, book.PrevUpdateId)
+		}
+		for _, entry := range book.Bids {
+			lb.bids.set(entry)
+		}
+		for _, entry := range book.Asks {
+			lb.asks.set(entry)
+		}
+	}
+
+	lb.updateId = book.UpdateId
+	lb.timestamp = book.Timestamp
+	return nil
+}
+
+// Resync replaces the book with a snapshot from the REST API.
+func (lb *LocalBook) Resync(ctx context.Context) error {
+	book, err := lb.client.OrderBookDepthContext(ctx, lb.Symbol, lb.depth)
+	if err != nil {
+		return err
+	}
+	book.UpdateId = 0
+	book.PrevUpdateId = 0
+	return lb.Apply(book)
+}
+
+// Run applies every update it receives until the channel is closed or ctx is done. When it detects a sequence gap, it
+// resyncs from the REST API, and then skips the updates that are not newer than the REST snapshot, e.g. because they were
+// already waiting in the channel.
+func (lb *LocalBook) Run(ctx context.Context, updates <-chan OrderBook) error {
+	synced := true
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case book, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if !synced {
+				lb.mutex.Lock()
+				stale := book.Timestamp <= lb.timestamp
+				if !stale {
+					// the REST snapshot has no sequence number, so we continue from the first delta after it
+					lb.updateId = book.PrevUpdateId
+				}
+				lb.mutex.Unlock()
+				if stale {
+					continue
+				}
+			}
+			err := lb.Apply(&book)
+			if errors.Is(err, ErrSequenceGap) {
+				if err := lb.Resync(ctx); err != nil {
+					return err
+				}
+				synced = false
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			synced = true
+		}
+	}
+}
+
+// Bids returns a copy of the bids, best first.
+func (lb *LocalBook) Bids() []BookEntry {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	return append([]BookEntry(nil), lb.bids.entries...)
+}
+
+// Asks returns a copy of the asks, best first.
+func (lb *LocalBook) Asks() []BookEntry {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	return append([]BookEntry(nil), lb.asks.entries...)
+}
+
+// BestBid returns the highest bid, or false if there aren't any bids.
+func (lb *LocalBook) BestBid() (BookEntry, bool) {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	if len(lb.bids.entries) == 0 {
+		return BookEntry{}, false
+	}
+	return lb.bids.entries[0], true
+}
+
+// BestAsk returns the lowest ask, or false if there aren't any asks.
+func (lb *LocalBook) BestAsk() (BookEntry, bool) {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	if len(lb.asks.entries) == 0 {
+		return BookEntry{}, false
+	}
+	return lb.asks.entries[0], true
+}
+
+// Depth returns the total bid size and ask size within bps basis points of the mid price.
+func (lb *LocalBook) Depth(bps float64) (bids, asks Decimal) {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	if len(lb.bids.entries) == 0 || len(lb.asks.entries) == 0 {
+		return decimal.Zero, decimal.Zero
+	}
+	mid := lb.bids.entries[0].Price.Add(lb.asks.entries[0].Price).Div(decimal.NewFromInt(2))
+	delta := mid.Mul(decimal.NewFromFloat(bps)).Div(decimal.NewFromInt(10000))
+	for _, entry := range lb.bids.entries {
+		if entry.Price.LessThan(mid.Sub(delta)) {
+			break
+		}
+		bids = bids.Add(entry.Size)
+	}
+	for _, entry := range lb.asks.entries {
+		if entry.Price.GreaterThan(mid.Add(delta)) {
+			break
+		}
+		asks = asks.Add(entry.Size)
+	}
+	return bids, asks
+}
+
+// VolumeTo returns the cumulative size a taker on side can fill up to and including price: a BUY walks the asks up,
+// a SELL walks the bids down.
+func (lb *LocalBook) VolumeTo(side OrderSide, price Decimal) Decimal {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	var volume Decimal
+	for _, entry := range lb.side(side) {
+		if (side == BUY && entry.Price.GreaterThan(price)) || (side == SELL && entry.Price.LessThan(price)) {
+			break
+		}
+		volume = volume.Add(entry.Size)
+	}
+	return volume
+}
+
+// VWAP returns the volume-weighted average price a taker on side pays (or receives) to fill size.
+func (lb *LocalBook) VWAP(side OrderSide, size Decimal) (Decimal, error) {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+	if !size.IsPositive() {
+		return decimal.Zero, fmt.Errorf("invalid size: %v", size)
+	}
+	var (
+		remaining = size
+		notional  Decimal
+	)
+	for _, entry := range lb.side(side) {
+		fill := decimal.Min(remaining, entry.Size)
+		notional = notional.Add(fill.Mul(entry.Price))
+		remaining = remaining.Sub(fill)
+		if !remaining.IsPositive() {
+			return notional.Div(size), nil
+		}
+	}
+	return decimal.Zero, fmt.Errorf("cannot %v %v %s: insufficient liquidity", side, size, lb.Symbol)
+}
+
+// side returns the levels a taker on side consumes.
+func (lb *LocalBook) side(side OrderSide) []BookEntry {
+	if side == BUY {
+		return lb.asks.entries
+	}
+	return lb.bids.entries
+}
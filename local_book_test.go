@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLocalBook(t *testing.T) {
+	book := New("", "").NewLocalBook("ETH_CRO", 10)
+
+	if err := book.Apply(&OrderBook{
+		Bids:     []BookEntry{{Price: dec("99"), Size: dec("2")}, {Price: dec("100"), Size: dec("1")}, {Price: dec("98"), Size: dec("3")}},
+		Asks:     []BookEntry{{Price: dec("102"), Size: dec("2")}, {Price: dec("101"), Size: dec("1")}, {Price: dec("103"), Size: dec("3")}},
+		UpdateId: 1,
+	}); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	if bid, _ := book.BestBid(); !bid.Price.Equal(dec("100")) {
+		t.Errorf("BestBid() returned %v, expected 100", bid.Price)
+	}
+	if ask, _ := book.BestAsk(); !ask.Price.Equal(dec("101")) {
+		t.Errorf("BestAsk() returned %v, expected 101", ask.Price)
+	}
+
+	// remove the best ask, change the best bid, add a new bid
+	if err := book.Apply(&OrderBook{
+		Bids:         []BookEntry{{Price: dec("100"), Size: dec("5")}, {Price: dec("99.5"), Size: dec("1")}},
+		Asks:         []BookEntry{{Price: dec("101"), Size: dec("0")}},
+		UpdateId:     2,
+		PrevUpdateId: 1,
+	}); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	if ask, _ := book.BestAsk(); !ask.Price.Equal(dec("102")) {
+		t.Errorf("BestAsk() returned %v, expected 102", ask.Price)
+	}
+	if bids := book.Bids(); len(bids) != 4 || !bids[0].Size.Equal(dec("5")) || !bids[1].Price.Equal(dec("99.5")) {
+		t.Errorf("Bids() returned %+v", bids)
+	}
+
+	// mid is 101, 100 bps is 99.99 to 102.01
+	if bids, asks := book.Depth(100); !bids.Equal(dec("5")) || !asks.Equal(dec("2")) {
+		t.Errorf("Depth(100) returned %v, %v, expected 5, 2", bids, asks)
+	}
+	if volume := book.VolumeTo(SELL, dec("99")); !volume.Equal(dec("8")) {
+		t.Errorf("VolumeTo(SELL, 99) returned %v, expected 8", volume)
+	}
+	if vwap, err := book.VWAP(BUY, dec("4")); err != nil || !vwap.Equal(dec("102.5")) {
+		t.Errorf("VWAP(BUY, 4) returned %v, %v, expected 102.5", vwap, err)
+	}
+	if _, err := book.VWAP(BUY, dec("100")); err == nil {
+		t.Error("VWAP(BUY, 100) did not return an error")
+	}
+
+	err := book.Apply(&OrderBook{UpdateId: 4, PrevUpdateId: 3})
+	if !errors.Is(err, ErrSequenceGap) {
+		t.Errorf("Apply() returned %v, expected %v", err, ErrSequenceGap)
+	}
+}
+
+func TestLocalBookRun(t *testing.T) {
+	var calls int32
+	server := newTestServer(t, map[string]testHandler{
+		"public/get-book": func(params map[string]interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			// the REST snapshot no longer has the ask at 101
+			return map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"bids": [][]string{{"100", "1", "1"}}, "asks": [][]string{{"102", "2", "1"}}, "t": 1000},
+				},
+			}
+		},
+	})
+	defer server.Close()
+
+	book := newTestClient(server).NewLocalBook("ETH_CRO", 10)
+
+	updates := make(chan OrderBook, 8)
+	updates <- OrderBook{
+		Bids:      []BookEntry{{Price: dec("100"), Size: dec("1")}},
+		Asks:      []BookEntry{{Price: dec("101"), Size: dec("1")}, {Price: dec("102"), Size: dec("2")}},
+		Timestamp: 500,
+		UpdateId:  1,
+	}
+	// a gap, which makes Run resync from the REST snapshot
+	updates <- OrderBook{UpdateId: 5, PrevUpdateId: 3, Timestamp: 800}
+	// a delta that is older than the REST snapshot
+	updates <- OrderBook{Asks: []BookEntry{{Price: dec("101"), Size: dec("7")}}, UpdateId: 6, PrevUpdateId: 5, Timestamp: 900}
+	// deltas that are newer than the REST snapshot
+	updates <- OrderBook{Bids: []BookEntry{{Price: dec("100.5"), Size: dec("1")}}, UpdateId: 7, PrevUpdateId: 6, Timestamp: 1100}
+	updates <- OrderBook{Asks: []BookEntry{{Price: dec("102"), Size: dec("3")}}, UpdateId: 8, PrevUpdateId: 7, Timestamp: 1200}
+	close(updates)
+
+	if err := book.Run(context.Background(), updates); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Run() resynced %d times, expected 1", n)
+	}
+	if ask, _ := book.BestAsk(); !ask.Price.Equal(dec("102")) || !ask.Size.Equal(dec("3")) {
+		t.Errorf("BestAsk() returned %+v, expected 102 with size 3", ask)
+	}
+	if bid, _ := book.BestBid(); !bid.Price.Equal(dec("100.5")) {
+		t.Errorf("BestBid() returned %+v, expected 100.5", bid)
+	}
+}
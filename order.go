@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -30,6 +32,16 @@ const (
 	IMMEDIATE_OR_CANCEL TimeInForce = "IMMEDIATE_OR_CANCEL"
 )
 
+type ContingencyType string
+
+const (
+	LIST ContingencyType = "LIST"
+	OCO  ContingencyType = "OCO"
+)
+
+// the maximum number of orders in a list
+const maxOrderList = 10
+
 type OrderStatus string
 
 const (
@@ -41,16 +53,20 @@ const (
 )
 
 type Order struct {
-	Status    OrderStatus `json:"status"`           // ACTIVE, CANCELED, FILLED, REJECTED or EXPIRED
-	Reason    interface{} `json:"reason,omitempty"` // reason -- only for REJECTED orders
-	Side      OrderSide   `json:"side"`             // BUY or SELL
-	Price     float64     `json:"price,omitempty"`
-	Quantity  float64     `json:"quantity"`
-	OrderId   string      `json:"order_id"`
-	CreatedAt int64       `json:"create_time"`
-	UpdatedAt int64       `json:"update_time"`
-	Type      OrderType   `json:"type"`
-	Symbol    string      `json:"instrument_name"`
+	Status        OrderStatus `json:"status"`           // ACTIVE, CANCELED, FILLED, REJECTED or EXPIRED
+	Reason        interface{} `json:"reason,omitempty"` // reason -- only for REJECTED orders
+	Side          OrderSide   `json:"side"`             // BUY or SELL
+	Price         Decimal     `json:"price,omitempty"`
+	Quantity      Decimal     `json:"quantity"`
+	OrderId       string      `json:"order_id"`
+	CreatedAt     int64       `json:"create_time"`
+	UpdatedAt     int64       `json:"update_time"`
+	Type          OrderType   `json:"type"`
+	Symbol        string      `json:"instrument_name"`
+	ClientOrderId string      `json:"client_oid,omitempty"`
+	TimeInForce   TimeInForce `json:"time_in_force,omitempty"`
+	ExecInst      ExecInst    `json:"exec_inst,omitempty"`
+	TriggerPrice  Decimal     `json:"trigger_price,omitempty"`
 }
 
 func (order *Order) GetCreatedAt() time.Time {
@@ -66,3 +82,172 @@ func (order *Order) GetUpdatedAt() time.Time {
 	}
 	return time.Time{}
 }
+
+// ErrInvalidOrder is returned when an OrderRequest fails validation, before anything is sent to the exchange.
+var ErrInvalidOrder = errors.New("invalid order")
+
+type ExecInst string
+
+const (
+	POST_ONLY ExecInst = "POST_ONLY"
+)
+
+type OrderRequest struct {
+	Symbol        string      // e.g. ETH_CRO, BTC_USDT
+	Side          OrderSide   // BUY or SELL
+	Type          OrderType   // LIMIT, MARKET, STOP_LOSS, STOP_LIMIT, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+	Quantity      Decimal     // order quantity, not for MARKET, STOP_LOSS or TAKE_PROFIT buys that use Notional
+	Price         Decimal     // LIMIT, STOP_LIMIT and TAKE_PROFIT_LIMIT only
+	Notional      Decimal     // the amount to spend, MARKET, STOP_LOSS and TAKE_PROFIT buys only
+	TimeInForce   TimeInForce // LIMIT only, defaults to GOOD_TILL_CANCEL
+	PostOnly      bool        // LIMIT only, cancel the order if it would match immediately
+	ClientOrderId string      // optional, up to 36 characters. makes it safe to retry the order
+	TriggerPrice  Decimal     // STOP_LOSS, STOP_LIMIT, TAKE_PROFIT and TAKE_PROFIT_LIMIT only
+}
+
+func (kind OrderType) isLimit() bool {
+	return kind == LIMIT || kind == STOP_LIMIT || kind == TAKE_PROFIT_LIMIT
+}
+
+func (kind OrderType) isTrigger() bool {
+	return kind == STOP_LOSS || kind == STOP_LIMIT || kind == TAKE_PROFIT || kind == TAKE_PROFIT_LIMIT
+}
+
+// notional returns the value of the order in the quote currency, or zero if that is unknown (e.g. a MARKET order to SELL).
+func (req *OrderRequest) notional() Decimal {
+	if !req.Notional.IsZero() {
+		return req.Notional
+	}
+	if !req.Price.IsZero() {
+		return req.Quantity.Mul(req.Price)
+	}
+	return req.Quantity.Mul(req.TriggerPrice)
+}
+
+// Validate returns an error that wraps ErrInvalidOrder if the exchange would reject this combination of parameters.
+func (req *OrderRequest) Validate() error {
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidOrder, fmt.Sprintf(format, a...))
+	}
+	if req.Symbol == "" {
+		return invalid("instrument name is required")
+	}
+	if req.Side != BUY && req.Side != SELL {
+		return invalid("unknown side %q", req.Side)
+	}
+	switch req.Type {
+	case LIMIT, MARKET, STOP_LOSS, STOP_LIMIT, TAKE_PROFIT, TAKE_PROFIT_LIMIT:
+	default:
+		return invalid("unknown order type %q", req.Type)
+	}
+	if req.Quantity.IsNegative() || req.Price.IsNegative() || req.Notional.IsNegative() || req.TriggerPrice.IsNegative() {
+		return invalid("quantity, price, notional and trigger price cannot be negative")
+	}
+	if req.Type.isLimit() {
+		if req.Price.IsZero() {
+			return invalid("price is required for %s orders", req.Type)
+		}
+		if req.Quantity.IsZero() {
+			return invalid("quantity is required for %s orders", req.Type)
+		}
+		if !req.Notional.IsZero() {
+			return invalid("notional is not supported for %s orders", req.Type)
+		}
+	} else {
+		if !req.Price.IsZero() {
+			return invalid("price is not supported for %s orders", req.Type)
+		}
+		if req.Side == SELL && !req.Notional.IsZero() {
+			return invalid("notional is not supported for %s %s orders", req.Side, req.Type)
+		}
+		if req.Quantity.IsZero() == req.Notional.IsZero() {
+			return invalid("either quantity or notional is required for %s %s orders", req.Side, req.Type)
+		}
+	}
+	if req.Type.isTrigger() {
+		if req.TriggerPrice.IsZero() {
+			return invalid("trigger price is required for %s orders", req.Type)
+		}
+	} else if !req.TriggerPrice.IsZero() {
+		return invalid("trigger price is not supported for %s orders", req.Type)
+	}
+	if req.Type != LIMIT {
+		if req.TimeInForce != "" {
+			return invalid("time in force is supported for LIMIT orders only")
+		}
+		if req.PostOnly {
+			return invalid("post-only is supported for LIMIT orders only")
+		}
+	}
+	switch req.TimeInForce {
+	case "", GOOD_TILL_CANCEL:
+	case FILL_OR_KILL, IMMEDIATE_OR_CANCEL:
+		if req.PostOnly {
+			return invalid("post-only orders cannot be %s", req.TimeInForce)
+		}
+	default:
+		return invalid("unknown time in force %q", req.TimeInForce)
+	}
+	if len(req.ClientOrderId) > 36 {
+		return invalid("client order id cannot be longer than 36 characters")
+	}
+	return nil
+}
+
+func (req *OrderRequest) params() map[string]interface{} {
+	params := make(map[string]interface{})
+	params["instrument_name"] = req.Symbol
+	params["side"] = req.Side
+	params["type"] = req.Type
+	if !req.Quantity.IsZero() {
+		params["quantity"] = number(req.Quantity)
+	}
+	if !req.Price.IsZero() {
+		params["price"] = number(req.Price)
+	}
+	if !req.Notional.IsZero() {
+		params["notional"] = number(req.Notional)
+	}
+	if req.TimeInForce != "" {
+		params["time_in_force"] = req.TimeInForce
+	}
+	if req.PostOnly {
+		params["exec_inst"] = POST_ONLY
+	}
+	if req.ClientOrderId != "" {
+		params["client_oid"] = req.ClientOrderId
+	}
+	if !req.TriggerPrice.IsZero() {
+		params["trigger_price"] = number(req.TriggerPrice)
+	}
+	return params
+}
+
+// OrderList groups the orders that were submitted together, e.g. the legs of an OCO.
+type OrderList struct {
+	ListId          string
+	ContingencyType ContingencyType // LIST or OCO
+	Symbol          string
+	Orders          []OrderRequest // for OCO: the STOP_LOSS leg, followed by the TAKE_PROFIT_LIMIT leg
+}
+
+// Validate returns an error that wraps ErrInvalidOrder if the exchange would reject this order list.
+func (list *OrderList) Validate() error {
+	for i := range list.Orders {
+		if err := list.Orders[i].Validate(); err != nil {
+			return err
+		}
+		if list.Orders[i].Symbol != list.Symbol {
+			return fmt.Errorf("%w: every order in a list must be for the same instrument", ErrInvalidOrder)
+		}
+	}
+	if list.ContingencyType == OCO {
+		if len(list.Orders) != 2 || list.Orders[0].Type != STOP_LOSS || list.Orders[1].Type != TAKE_PROFIT_LIMIT {
+			return fmt.Errorf("%w: OCO requires a STOP_LOSS and a TAKE_PROFIT_LIMIT order", ErrInvalidOrder)
+		}
+		if list.Orders[0].Side != list.Orders[1].Side {
+			return fmt.Errorf("%w: both OCO orders must be on the same side", ErrInvalidOrder)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOrderRequestValidate(t *testing.T) {
+	valid := []OrderRequest{
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100"), TimeInForce: GOOD_TILL_CANCEL, PostOnly: true},
+		{Symbol: "ETH_CRO", Side: BUY, Type: MARKET, Notional: dec("100")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: MARKET, Quantity: dec("1")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: STOP_LOSS, Quantity: dec("1"), TriggerPrice: dec("90")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: TAKE_PROFIT_LIMIT, Quantity: dec("1"), Price: dec("110"), TriggerPrice: dec("109"), ClientOrderId: "my-order"},
+	}
+	for _, req := range valid {
+		if err := req.Validate(); err != nil {
+			t.Errorf("Validate(%+v) failed: %v", req, err)
+		}
+	}
+
+	invalid := []OrderRequest{
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1")},
+		{Symbol: "ETH_CRO", Side: BUY, Type: MARKET, Quantity: dec("1"), Notional: dec("100")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: MARKET, Notional: dec("100")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: STOP_LIMIT, Quantity: dec("1"), Price: dec("90")},
+		{Symbol: "ETH_CRO", Side: SELL, Type: MARKET, Quantity: dec("1"), TimeInForce: FILL_OR_KILL},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100"), TimeInForce: IMMEDIATE_OR_CANCEL, PostOnly: true},
+		{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("100"), ClientOrderId: "0123456789012345678901234567890123456789"},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("Validate(%+v) returned %v, expected %v", req, err, ErrInvalidOrder)
+		}
+	}
+}
+
+func TestCreateOrders(t *testing.T) {
+	var (
+		lists int
+		calls int32
+	)
+
+	server := newTestServer(t, map[string]testHandler{"public/get-instruments": instruments(&calls, nil), "": func(params map[string]interface{}) interface{} {
+		lists++
+		orders := params["order_list"].([]interface{})
+		if len(orders) > maxOrderList {
+			t.Errorf("order list has %d orders, expected at most %d", len(orders), maxOrderList)
+		}
+		var results []map[string]interface{}
+		for i, order := range orders {
+			if order.(map[string]interface{})["price"] == float64(0.5) {
+				results = append(results, map[string]interface{}{"index": i, "code": CODE_MIN_PRICE_VIOLATED, "message": "MIN_PRICE_VIOLATED"})
+			} else {
+				results = append(results, map[string]interface{}{"index": i, "code": 0, "order_id": fmt.Sprint(order.(map[string]interface{})["price"])})
+			}
+		}
+		return map[string]interface{}{"result_list": results}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	var reqs []OrderRequest
+	for i := 1; i <= 12; i++ {
+		reqs = append(reqs, OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec(fmt.Sprint(i))})
+	}
+	reqs = append(reqs, OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1")})                       // invalid: no price
+	reqs = append(reqs, OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1"), Price: dec("0.5")})    // rejected by the exchange
+	reqs = append(reqs, OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1.0001"), Price: dec("1")}) // invalid: too many decimals
+
+	results, err := client.CreateOrders(reqs)
+	if err == nil {
+		t.Error("CreateOrders() did not return an error")
+	}
+	if lists != 2 {
+		t.Errorf("CreateOrders() submitted %d lists, expected 2", lists)
+	}
+	for i := 0; i < 12; i++ {
+		if results[i].Err != nil || results[i].OrderId != fmt.Sprint(i+1) {
+			t.Errorf("CreateOrders() returned %+v for order %d", results[i], i)
+		}
+	}
+	if !errors.Is(results[12].Err, ErrInvalidOrder) {
+		t.Errorf("CreateOrders() returned %v for order 12, expected %v", results[12].Err, ErrInvalidOrder)
+	}
+	if !hasCode(results[13].Err, CODE_MIN_PRICE_VIOLATED) {
+		t.Errorf("CreateOrders() returned %v for order 13, expected MIN_PRICE_VIOLATED", results[13].Err)
+	}
+	if !errors.Is(results[14].Err, ErrInvalidOrder) {
+		t.Errorf("CreateOrders() returned %v for order 14, expected %v", results[14].Err, ErrInvalidOrder)
+	}
+
+	// PlaceOrder checks the trading rules before anything is sent to the exchange
+	if _, err := client.PlaceOrder(&OrderRequest{Symbol: "ETH_CRO", Side: BUY, Type: LIMIT, Quantity: dec("1000"), Price: dec("1")}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("PlaceOrder() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+}
+
+func TestParamString(t *testing.T) {
+	list := []map[string]interface{}{
+		{"side": BUY, "price": 1.5, "quantity": 2},
+		{"side": SELL, "price": 2.5, "quantity": 3},
+	}
+	if s := paramString(list); s != "price1.5quantity2sideBUYprice2.5quantity3sideSELL" {
+		t.Errorf("paramString() returned %s", s)
+	}
+}
+
+func TestCreateOCO(t *testing.T) {
+	var calls int32
+
+	server := newTestServer(t, map[string]testHandler{"public/get-instruments": instruments(&calls, nil), "": func(params map[string]interface{}) interface{} {
+		if params["contingency_type"] != string(OCO) {
+			t.Errorf("unexpected contingency type: %v", params["contingency_type"])
+		}
+		if _, ok := params["list_id"]; ok {
+			return nil // private/cancel-order-list
+		}
+		return json.RawMessage(`{"list_id":6498090546073120100}`)
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	var (
+		stopLoss   = OrderRequest{Symbol: "ETH_CRO", Side: SELL, Type: STOP_LOSS, Quantity: dec("1"), TriggerPrice: dec("90")}
+		takeProfit = OrderRequest{Symbol: "ETH_CRO", Side: SELL, Type: TAKE_PROFIT_LIMIT, Quantity: dec("1"), Price: dec("110"), TriggerPrice: dec("109")}
+	)
+
+	if _, err := client.CreateOCO(&takeProfit, &stopLoss); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOCO() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+	if _, err := client.CreateOCO(&stopLoss, nil); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOCO() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+	if _, err := client.CreateOCO(nil, &takeProfit); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOCO() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+	tooPrecise := takeProfit
+	tooPrecise.Price = dec("110.001")
+	if _, err := client.CreateOCO(&stopLoss, &tooPrecise); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOCO() returned %v, expected %v", err, ErrInvalidOrder)
+	}
+
+	list, err := client.CreateOCO(&stopLoss, &takeProfit)
+	if err != nil {
+		t.Fatalf("CreateOCO() failed: %v", err)
+	}
+	if list.ListId != "6498090546073120100" || len(list.Orders) != 2 {
+		t.Errorf("CreateOCO() returned %+v", list)
+	}
+
+	if err := client.CancelOrderList(list); err != nil {
+		t.Errorf("CancelOrderList() failed: %v", err)
+	}
+	for _, list := range []*OrderList{nil, {ContingencyType: OCO, Symbol: "ETH_CRO"}, {ContingencyType: OCO, ListId: list.ListId}} {
+		if err := client.CancelOrderList(list); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CancelOrderList(%+v) returned %v, expected %v", list, err, ErrInvalidOrder)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type rateLimit int
+
+const (
+	RATE_LIMIT_NORMAL rateLimit = iota
+	RATE_LIMIT_COOL_DOWN
+)
+
+var RequestsPerSecond = map[rateLimit]float64{
+	RATE_LIMIT_NORMAL:    100,           // 100 req/second (default)
+	RATE_LIMIT_COOL_DOWN: 0.01666666667, // 1 req/minute
+}
+
+// RateLimiter throttles the requests of a single Client. Implementations must be safe for concurrent use.
+type RateLimiter interface {
+	// Wait blocks until a request to path may be sent at no more than rps requests per second, or until ctx is done.
+	Wait(ctx context.Context, method, path string, rps float64) error
+	// OnRateLimitError is called when the exchange responds with HTTP 429 Too Many Requests.
+	OnRateLimitError(method, path string)
+}
+
+// bucket is a token bucket that refills at rate tokens per second, up to burst tokens.
+type bucket struct {
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	cooldown time.Time // no requests before this time
+}
+
+func newBucket(rps float64) *bucket {
+	// the exchange counts requests per 100ms, so that is the largest burst we allow
+	burst := math.Max(1, math.Floor(rps/10))
+	return &bucket{
+		rate:   rps,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller needs to wait before it can use it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if b.cooldown.After(now.Add(wait)) {
+		wait = b.cooldown.Sub(now)
+	}
+	return wait
+}
+
+// tokenBucket is the default RateLimiter. It keeps a separate bucket per endpoint group, e.g. private/create-order and
+// private/cancel-order share the 150 req/second bucket, while private/get-trades has a 1 req/second bucket of its own.
+type tokenBucket struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket // by group
+	groups  map[string]string  // by path
+}
+
+// NewRateLimiter returns a goroutine-safe token bucket RateLimiter with a separate bucket per endpoint group. Endpoints
+// are grouped by their HTTP method and rate limit.
+func NewRateLimiter() RateLimiter {
+	return &tokenBucket{
+		buckets: make(map[string]*bucket),
+		groups:  make(map[string]string),
+	}
+}
+
+// group returns the name of the bucket that endpoints with this method and rate limit share, e.g. "POST 150".
+func group(method string, rps float64) string {
+	return fmt.Sprintf("%s %v", method, rps)
+}
+
+func (tb *tokenBucket) get(method, path string, rps float64) *bucket {
+	if rps <= 0 {
+		rps = RequestsPerSecond[RATE_LIMIT_NORMAL]
+	}
+	key := group(method, rps)
+	tb.groups[path] = key
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = newBucket(rps)
+		tb.buckets[key] = b
+	}
+	return b
+}
+
+func (tb *tokenBucket) Wait(ctx context.Context, method, path string, rps float64) error {
+	tb.mutex.Lock()
+	b := tb.get(method, path, rps)
+	wait := b.reserve(time.Now())
+	tb.mutex.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// give the token back, we didn't use it
+		tb.mutex.Lock()
+		b.tokens = math.Min(b.burst, b.tokens+1)
+		tb.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (tb *tokenBucket) OnRateLimitError(method, path string) {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+	if b, ok := tb.buckets[tb.groups[path]]; ok {
+		b.cooldown = time.Now().Add(time.Duration(float64(time.Second) / RequestsPerSecond[RATE_LIMIT_COOL_DOWN]))
+	}
+}
+
+func (client *Client) wait(ctx context.Context, method, path string, rps float64) error {
+	if client.RateLimiter == nil {
+		return ctx.Err()
+	}
+	return client.RateLimiter.Wait(ctx, method, path, rps)
+}
+
+func (client *Client) onRateLimitError(method, path string) {
+	if client.RateLimiter != nil {
+		client.RateLimiter.OnRateLimitError(method, path)
+	}
+}
+
+// sleep pauses the current goroutine for at least the duration d, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background(), "POST", "private/get-trades", 10); err != nil {
+			t.Fatalf("Wait() failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Wait() did not throttle: 3 requests at 10 req/second took %v", elapsed)
+	}
+
+	// endpoints with the same rate limit share a bucket
+	start = time.Now()
+	if err := limiter.Wait(context.Background(), "POST", "private/get-order-history", 10); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Wait() did not throttle an endpoint in the same group: took %v", elapsed)
+	}
+
+	// endpoints with a different rate limit have their own bucket
+	start = time.Now()
+	if err := limiter.Wait(context.Background(), "POST", "private/create-order", 150); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait() throttled a different endpoint group for %v", elapsed)
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.OnRateLimitError("POST", "private/get-trades") // no bucket yet, ignored
+
+	if err := limiter.Wait(context.Background(), "POST", "private/get-trades", 1); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx, "POST", "private/get-trades", 1); err != context.DeadlineExceeded {
+		t.Errorf("Wait() returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
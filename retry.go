@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy determines if and when a Client retries a failed request.
+type RetryPolicy struct {
+	MaxAttempts int                  // the maximum number of attempts, including the first one. zero means no limit
+	MinBackoff  time.Duration        // the delay before the first retry
+	MaxBackoff  time.Duration        // the maximum delay between retries
+	Retryable   func(err error) bool // nil means IsRetryable
+}
+
+// DefaultRetryPolicy returns the RetryPolicy every new Client starts with.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// DefaultReconnectPolicy returns the RetryPolicy every new Client reconnects its streams with.
+func DefaultReconnectPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 0,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+	}
+}
+
+// IsRetryable returns true if err is a transient failure: a network error, a timeout, HTTP 429 or HTTP 5xx.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if IsRateLimited(err) {
+		return true
+	}
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode >= http.StatusInternalServerError || apiError.Code == CODE_SYS_ERROR
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netError net.Error
+	return errors.As(err, &netError)
+}
+
+// idempotencyKeys maps the non-idempotent endpoints onto the parameter that makes a retry idempotent.
+var idempotencyKeys = map[string]string{
+	"private/create-order":      "client_oid",
+	"private/create-order-list": "", // never safe to retry
+	"private/create-withdrawal": "client_wid",
+}
+
+// idempotent returns true if the request can be sent more than once without side effects.
+func (client *Client) idempotent(path string, params map[string]interface{}) bool {
+	key, ok := idempotencyKeys[path]
+	if !ok {
+		return true
+	}
+	value, ok := params[key]
+	return ok && value != nil && value != ""
+}
+
+func (policy *RetryPolicy) retryable(attempt int, idempotent bool, err error) bool {
+	if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+		return false
+	}
+	// the exchange does not process requests that exceed the rate limits, so we can always retry those
+	if !idempotent && !IsRateLimited(err) {
+		return false
+	}
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the delay before the next attempt: exponential with jitter, or Retry-After if the exchange sent one.
+func (policy *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.RetryAfter > 0 {
+		return apiError.RetryAfter
+	}
+	delay := policy.MinBackoff
+	for i := 1; i < attempt && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// wait somewhere between half and the full delay
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retry calls call until it succeeds, or until the RetryPolicy gives up.
+func (client *Client) retry(ctx context.Context, idempotent bool, call func() ([]byte, error)) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		data, err := call()
+		if err == nil || client.RetryPolicy == nil || !client.RetryPolicy.retryable(attempt, idempotent, err) {
+			return data, err
+		}
+		if err := sleep(ctx, client.RetryPolicy.backoff(attempt, err)); err != nil {
+			return nil, err
+		}
+	}
+}
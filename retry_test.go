@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":0,"code":0,"result":{"data":[{"bids":[],"asks":[]}]}}`))
+	}))
+	defer server.Close()
+
+	client := New("", "")
+	client.URL = server.URL + "/"
+	client.RetryPolicy.MinBackoff = time.Millisecond
+
+	// idempotent requests are retried
+	if _, err := client.OrderBook("ETH_BTC"); err != nil || attempts != 3 {
+		t.Errorf("OrderBook() returned %v after %d attempts, expected 3 attempts", err, attempts)
+	}
+
+	// non-idempotent requests are not
+	attempts = 0
+	_, err := client.post(context.Background(), "private/create-order", map[string]interface{}{"instrument_name": "ETH_BTC"}, 0)
+	if !IsRetryable(err) || attempts != 1 {
+		t.Errorf("post() returned %v after %d attempts, expected 1 attempt", err, attempts)
+	}
+
+	// unless they carry a client order id
+	attempts = 0
+	_, err = client.post(context.Background(), "private/create-order", map[string]interface{}{"client_oid": "my-order"}, 0)
+	if err != nil || attempts != 3 {
+		t.Errorf("post() returned %v after %d attempts, expected 3 attempts", err, attempts)
+	}
+}
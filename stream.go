@@ -0,0 +1,544 @@
+package crypto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	marketStreamEndpoint = "wss://stream.crypto.com/v2/market"
+	userStreamEndpoint   = "wss://stream.crypto.com/v2/user"
+)
+
+const (
+	// the number of messages a subscription channel can hold before the stream blocks on it
+	streamBufferSize = 256
+	// the exchange sends a heartbeat every 30 seconds, we reconnect if we haven't heard from it for twice that long
+	heartbeatTimeout = 60 * time.Second
+)
+
+var (
+	// ErrStreamClosed is returned when you subscribe to a stream that has been closed.
+	ErrStreamClosed = errors.New("stream closed")
+	// ErrConnectionLost is returned when the connection drops while a request is waiting for its response.
+	ErrConnectionLost = errors.New("connection lost")
+)
+
+type StreamState string
+
+const (
+	STREAM_CONNECTED    StreamState = "CONNECTED"    // the stream is connected
+	STREAM_DISCONNECTED StreamState = "DISCONNECTED" // the connection dropped, data may have gaps until the stream reconnects
+	STREAM_RECONNECTED  StreamState = "RECONNECTED"  // the stream reconnected and re-subscribed every channel
+	STREAM_CLOSED       StreamState = "CLOSED"       // the stream was closed, or gave up reconnecting
+	STREAM_ERROR        StreamState = "ERROR"        // a message could not be decoded and was skipped
+)
+
+type StreamEvent struct {
+	State StreamState
+	Err   error // the reason for DISCONNECTED, CLOSED or ERROR, if any
+	Time  time.Time
+}
+
+type streamRequest struct {
+	Id     int64                  `json:"id"`
+	Method string                 `json:"method"`
+	ApiKey string                 `json:"api_key,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+	Sig    string                 `json:"sig,omitempty"`
+	Nonce  int64                  `json:"nonce"`
+}
+
+type streamResponse struct {
+	Id      int64           `json:"id"`
+	Method  string          `json:"method"`
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+func (response *streamResponse) err() error {
+	if response.Code == 0 {
+		return nil
+	}
+	return &APIError{
+		Code:      response.Code,
+		Message:   response.Message,
+		Method:    "WS",
+		Path:      response.Method,
+		RequestId: response.Id,
+	}
+}
+
+// handler decodes the data of a subscription and sends it to the subscriber. It must return early when quit is closed.
+type handler func(data json.RawMessage, quit <-chan struct{}) error
+
+// forward returns a handler that decodes the data of a subscription into a slice of the element type of out, which must
+// be a channel, and sends the elements to out one at a time.
+func forward(out interface{}) handler {
+	ch := reflect.ValueOf(out)
+	typ := reflect.SliceOf(ch.Type().Elem())
+	return func(data json.RawMessage, quit <-chan struct{}) error {
+		items := reflect.New(typ)
+		if err := json.Unmarshal(data, items.Interface()); err != nil {
+			return err
+		}
+		items = items.Elem()
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: ch},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+		}
+		for i := 0; i < items.Len(); i++ {
+			cases[0].Send = items.Index(i)
+			if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+type subscription struct {
+	mutex  sync.Mutex // guarantees we never send on a closed channel
+	handle handler
+	close  func() // closes the subscriber's channel
+	closed bool
+	quit   chan struct{} // closed when we start shutting down, so a handler blocked on a full channel returns
+	once   sync.Once
+}
+
+func newSubscription(handle handler, close func()) *subscription {
+	return &subscription{handle: handle, close: close, quit: make(chan struct{})}
+}
+
+func (sub *subscription) dispatch(data json.RawMessage) error {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	if sub.closed {
+		return nil
+	}
+	return sub.handle(data, sub.quit)
+}
+
+// stop tells the handler to give up sending, without waiting for it.
+func (sub *subscription) stop() {
+	sub.once.Do(func() {
+		close(sub.quit)
+	})
+}
+
+func (sub *subscription) shutdown() {
+	sub.stop()
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	if !sub.closed {
+		sub.closed = true
+		sub.close()
+	}
+}
+
+// stream is a WebSocket connection that dispatches channel data to its subscriptions. When the connection drops, the
+// stream reconnects, authenticates and re-subscribes every channel.
+type stream struct {
+	url     string
+	auth    func(ctx context.Context, s *stream) error // nil for public streams
+	policy  *RetryPolicy                               // nil disables reconnecting
+	cancel  context.CancelFunc                         // cancels reconnecting
+	writer  sync.Mutex                                 // gorilla supports one concurrent writer only
+	mutex   sync.Mutex                                 // guards everything below
+	conn    *websocket.Conn
+	nextId  int64
+	pending map[int64]chan *streamResponse
+	subs    map[string]*subscription
+	closed  bool
+	quit    chan struct{} // closed when we start shutting down
+	done    chan struct{} // closed when we have shut down
+	events  chan StreamEvent
+	err     error
+}
+
+func dial(ctx context.Context, url string, auth func(ctx context.Context, s *stream) error, policy *RetryPolicy) (*stream, error) {
+	s := &stream{
+		url:     url,
+		auth:    auth,
+		policy:  policy,
+		nextId:  time.Now().UnixNano() / int64(time.Millisecond),
+		pending: make(map[int64]chan *streamResponse),
+		subs:    make(map[string]*subscription),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+		events:  make(chan StreamEvent, 16),
+	}
+	lost, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.emit(STREAM_CONNECTED, nil)
+
+	var background context.Context
+	background, s.cancel = context.WithCancel(context.Background())
+	go s.run(background, lost)
+
+	return s, nil
+}
+
+// connect dials the exchange, authenticates and re-subscribes every channel. It returns a channel that receives the
+// reason the connection dropped.
+func (s *stream) connect(ctx context.Context) (<-chan error, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		conn.Close()
+		return nil, ErrStreamClosed
+	}
+	s.conn = conn
+	channels := make([]string, 0, len(s.subs))
+	for channel := range s.subs {
+		channels = append(channels, channel)
+	}
+	s.mutex.Unlock()
+
+	lost := make(chan error, 1)
+	go func() {
+		lost <- s.read(conn)
+	}()
+
+	if err := func() error {
+		// the exchange recommends to wait one second before sending requests, because of the rate limits being pro-rated
+		if err := sleep(ctx, time.Second); err != nil {
+			return err
+		}
+		if s.auth != nil {
+			if err := s.auth(ctx, s); err != nil {
+				return err
+			}
+		}
+		if len(channels) > 0 {
+			if _, err := s.call(ctx, &streamRequest{
+				Method: "subscribe",
+				Params: map[string]interface{}{
+					"channels": channels,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		conn.Close()
+		<-lost
+		return nil, err
+	}
+
+	return lost, nil
+}
+
+// run waits for the connection to drop, and then reconnects until it succeeds, the stream is closed, or the policy
+// gives up.
+func (s *stream) run(ctx context.Context, lost <-chan error) {
+	var err error
+	defer func() {
+		s.shutdown(err)
+	}()
+
+	for {
+		err = <-lost
+		if s.isClosed() {
+			err = nil
+			return
+		}
+		s.emit(STREAM_DISCONNECTED, err)
+
+		if s.policy == nil {
+			return
+		}
+		for attempt := 1; ; attempt++ {
+			if sleep(ctx, s.policy.backoff(attempt, err)) != nil {
+				err = nil // closed while we were waiting
+				return
+			}
+			if lost, err = s.connect(ctx); err == nil {
+				break
+			}
+			if s.isClosed() {
+				err = nil
+				return
+			}
+			if !s.reconnectable(attempt, err) {
+				return
+			}
+		}
+		s.emit(STREAM_RECONNECTED, nil)
+	}
+}
+
+// reconnectable returns true if the stream should try to reconnect after err. Unlike requests, we keep trying after
+// network and protocol errors, and only give up when the exchange rejects us (e.g. because of invalid credentials).
+func (s *stream) reconnectable(attempt int, err error) bool {
+	var apiError *APIError
+	if s.policy.Retryable == nil && !errors.As(err, &apiError) {
+		return s.policy.MaxAttempts <= 0 || attempt < s.policy.MaxAttempts
+	}
+	return s.policy.retryable(attempt, true, err)
+}
+
+// shutdown closes every subscription channel and the events channel.
+func (s *stream) shutdown(err error) {
+	s.mutex.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.quit)
+	}
+	s.err = err
+	subs := s.subs
+	s.subs = make(map[string]*subscription)
+	s.mutex.Unlock()
+
+	for _, sub := range subs {
+		sub.shutdown()
+	}
+	s.emit(STREAM_CLOSED, err)
+	close(s.events)
+	close(s.done)
+}
+
+// emit sends an event without blocking. When nobody is listening, ERROR events are dropped once they fill half of the
+// buffer, and state changes drop the oldest events to make room, so the latest state always gets through.
+func (s *stream) emit(state StreamState, err error) {
+	event := StreamEvent{State: state, Err: err, Time: time.Now()}
+	if state == STREAM_ERROR {
+		if len(s.events) < cap(s.events)/2 {
+			select {
+			case s.events <- event:
+			default:
+			}
+		}
+		return
+	}
+	for {
+		select {
+		case s.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-s.events:
+		default:
+		}
+	}
+}
+
+func (s *stream) isClosed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.closed
+}
+
+// Events returns a channel that receives the connection state changes, and the messages the stream could not decode.
+// If you don't read them, older events are dropped, but the latest state change always gets through.
+func (s *stream) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Done returns a channel that is closed when the stream is closed, or when it gives up reconnecting.
+func (s *stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the reason the stream gave up reconnecting, or nil if the stream was closed by calling Close.
+func (s *stream) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// Close disconnects the stream and closes every subscription channel.
+func (s *stream) Close() error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.quit)
+	for _, sub := range s.subs {
+		sub.stop()
+	}
+	conn := s.conn
+	s.mutex.Unlock()
+	s.cancel()
+	return conn.Close()
+}
+
+func (s *stream) write(request *streamRequest) error {
+	if request.Nonce == 0 {
+		request.Nonce = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	s.mutex.Lock()
+	conn := s.conn
+	s.mutex.Unlock()
+	s.writer.Lock()
+	defer s.writer.Unlock()
+	return conn.WriteJSON(request)
+}
+
+// id returns a new request id.
+func (s *stream) id() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextId++
+	return s.nextId
+}
+
+// call sends a request and waits for the response. If the request does not have an id yet, call gives it one.
+func (s *stream) call(ctx context.Context, request *streamRequest) (*streamResponse, error) {
+	if request.Id == 0 {
+		request.Id = s.id()
+	}
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil, ErrStreamClosed
+	}
+	reply := make(chan *streamResponse, 1)
+	s.pending[request.Id] = reply
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.pending, request.Id)
+		s.mutex.Unlock()
+	}()
+
+	if err := s.write(request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response, ok := <-reply:
+		if !ok {
+			return nil, ErrConnectionLost
+		}
+		return response, response.err()
+	case <-s.quit:
+		return nil, ErrStreamClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// subscribe subscribes to channel and calls handle for every message the exchange publishes on it.
+func (s *stream) subscribe(ctx context.Context, channel string, handle handler, close func()) error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		close()
+		return ErrStreamClosed
+	}
+	old := s.subs[channel]
+	s.subs[channel] = newSubscription(handle, close)
+	s.mutex.Unlock()
+
+	if old != nil {
+		old.shutdown()
+	}
+
+	_, err := s.call(ctx, &streamRequest{
+		Method: "subscribe",
+		Params: map[string]interface{}{
+			"channels": []string{channel},
+		},
+	})
+	if err != nil {
+		s.unsubscribe(channel)
+	}
+	return err
+}
+
+// unsubscribe removes the subscription and closes its channel.
+func (s *stream) unsubscribe(channel string) {
+	s.mutex.Lock()
+	sub, ok := s.subs[channel]
+	delete(s.subs, channel)
+	s.mutex.Unlock()
+	if ok {
+		sub.shutdown()
+	}
+}
+
+// read answers heartbeats, routes responses to their callers and data to the subscriptions, until the connection drops.
+func (s *stream) read(conn *websocket.Conn) (err error) {
+	defer func() {
+		conn.Close()
+		// fail every request that is waiting for a response on this connection
+		s.mutex.Lock()
+		for id, reply := range s.pending {
+			close(reply)
+			delete(s.pending, id)
+		}
+		s.mutex.Unlock()
+	}()
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(heartbeatTimeout)); err != nil {
+			return
+		}
+
+		var message []byte
+		if _, message, err = conn.ReadMessage(); err != nil {
+			return
+		}
+		var response streamResponse
+		if err := json.Unmarshal(message, &response); err != nil {
+			s.emit(STREAM_ERROR, err)
+			continue
+		}
+
+		if response.Method == "public/heartbeat" {
+			if err = s.write(&streamRequest{
+				Id:     response.Id,
+				Method: "public/respond-heartbeat",
+			}); err != nil {
+				return
+			}
+			continue
+		}
+
+		var result struct {
+			Subscription string          `json:"subscription"`
+			Data         json.RawMessage `json:"data"`
+		}
+		if response.Result != nil && json.Unmarshal(response.Result, &result) == nil && result.Subscription != "" {
+			s.mutex.Lock()
+			sub, ok := s.subs[result.Subscription]
+			s.mutex.Unlock()
+			if ok {
+				if err := sub.dispatch(result.Data); err != nil {
+					s.emit(STREAM_ERROR, err)
+				}
+			}
+			continue
+		}
+
+		s.mutex.Lock()
+		reply, ok := s.pending[response.Id]
+		s.mutex.Unlock()
+		if ok {
+			select {
+			case reply <- &response:
+			default:
+			}
+		}
+	}
+}
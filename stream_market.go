@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+)
+
+// MarketStream delivers market data over a WebSocket connection that reconnects automatically. Every subscription returns
+// a channel that is closed when the stream is closed. Read from your channels promptly: the stream blocks when a channel
+// is full.
+type MarketStream struct {
+	*stream
+}
+
+// MarketStream connects to the market data WebSocket.
+func (client *Client) MarketStream(ctx context.Context) (*MarketStream, error) {
+	s, err := dial(ctx, client.MarketStreamURL, nil, client.ReconnectPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &MarketStream{s}, nil
+}
+
+// SubscribeBook subscribes to book.{symbol}.{depth}, e.g. book.ETH_CRO.150
+func (ms *MarketStream) SubscribeBook(ctx context.Context, symbol string, depth int) (<-chan OrderBook, error) {
+	out := make(chan OrderBook, streamBufferSize)
+	return out, ms.subscribe(ctx, fmt.Sprintf("book.%s.%d", symbol, depth), forward(out), func() { close(out) })
+}
+
+// SubscribeTicker subscribes to ticker.{symbol}, e.g. ticker.ETH_CRO
+func (ms *MarketStream) SubscribeTicker(ctx context.Context, symbol string) (<-chan Ticker, error) {
+	out := make(chan Ticker, streamBufferSize)
+	return out, ms.subscribe(ctx, fmt.Sprintf("ticker.%s", symbol), forward(out), func() { close(out) })
+}
+
+// SubscribeTrades subscribes to trade.{symbol}, e.g. trade.ETH_CRO
+func (ms *MarketStream) SubscribeTrades(ctx context.Context, symbol string) (<-chan PublicTrade, error) {
+	out := make(chan PublicTrade, streamBufferSize)
+	return out, ms.subscribe(ctx, fmt.Sprintf("trade.%s", symbol), forward(out), func() { close(out) })
+}
+
+// SubscribeCandlesticks subscribes to candlestick.{interval}.{symbol}, e.g. candlestick.1h.ETH_CRO
+func (ms *MarketStream) SubscribeCandlesticks(ctx context.Context, symbol string, interval Interval) (<-chan Candle, error) {
+	out := make(chan Candle, streamBufferSize)
+	return out, ms.subscribe(ctx, fmt.Sprintf("candlestick.%s.%s", interval, symbol), forward(out), func() { close(out) })
+}
@@ -0,0 +1,334 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newStreamServer returns a WebSocket server that sends a heartbeat, acknowledges every subscription and then publishes data.
+func newStreamServer(t *testing.T, data ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade() failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]interface{}{"id": 42, "method": "public/heartbeat"})
+
+		for {
+			var request streamRequest
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			switch request.Method {
+			case "public/respond-heartbeat":
+				if request.Id != 42 {
+					t.Errorf("heartbeat response has id %d, expected 42", request.Id)
+				}
+			case "public/auth":
+				code := 0
+				if request.Sig != New("key", "secret").sign(request.Method, request.Id, nil, request.Nonce) {
+					code = int(CODE_UNAUTHORIZED)
+				}
+				conn.WriteJSON(map[string]interface{}{"id": request.Id, "method": "public/auth", "code": code})
+			case "subscribe":
+				conn.WriteJSON(map[string]interface{}{"id": request.Id, "method": "subscribe", "code": 0})
+				for _, message := range data {
+					conn.WriteMessage(websocket.TextMessage, []byte(message))
+				}
+			}
+		}
+	}))
+}
+
+func TestMarketStream(t *testing.T) {
+	server := newStreamServer(t, `{"id":-1,"method":"subscribe","code":0,"result":{"instrument_name":"ETH_CRO","subscription":"ticker.ETH_CRO","channel":"ticker","data":[{"i":"ETH_CRO","b":2000.5,"k":2001.5,"a":2001,"t":1591704180270}]}}`)
+	defer server.Close()
+
+	client := New("", "")
+	client.MarketStreamURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.MarketStream(ctx)
+	if err != nil {
+		t.Fatalf("MarketStream() failed: %v", err)
+	}
+
+	tickers, err := stream.SubscribeTicker(ctx, "ETH_CRO")
+	if err != nil {
+		t.Fatalf("SubscribeTicker() failed: %v", err)
+	}
+
+	select {
+	case ticker := <-tickers:
+		if ticker.Symbol != "ETH_CRO" || !ticker.Bid.Equal(dec("2000.5")) || !ticker.Ask.Equal(dec("2001.5")) {
+			t.Errorf("unexpected ticker: %+v", ticker)
+		}
+	case <-ctx.Done():
+		t.Fatal("SubscribeTicker() did not deliver a ticker")
+	}
+
+	stream.Close()
+	if _, ok := <-tickers; ok {
+		t.Error("Close() did not close the ticker channel")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() returned %v after Close()", err)
+	}
+}
+
+func TestUserStream(t *testing.T) {
+	server := newStreamServer(t, `{"id":-1,"method":"subscribe","code":0,"result":{"subscription":"user.balance","channel":"user.balance","data":[{"currency":"CRO","balance":100,"available":75,"order":25,"stake":0}]}}`)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := New("key", "wrong secret")
+	client.UserStreamURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	if _, err := client.UserStream(ctx); err == nil {
+		t.Fatal("UserStream() authenticated with the wrong secret")
+	}
+
+	client.Secret = "secret"
+
+	stream, err := client.UserStream(ctx)
+	if err != nil {
+		t.Fatalf("UserStream() failed: %v", err)
+	}
+	defer stream.Close()
+
+	balances, err := stream.SubscribeBalance(ctx)
+	if err != nil {
+		t.Fatalf("SubscribeBalance() failed: %v", err)
+	}
+
+	select {
+	case account := <-balances:
+		if account.Currency != "CRO" || !account.Available.Equal(dec("75")) {
+			t.Errorf("unexpected account: %+v", account)
+		}
+	case <-ctx.Done():
+		t.Fatal("SubscribeBalance() did not deliver a balance")
+	}
+}
+
+func TestStreamReconnect(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade() failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		connection := atomic.AddInt32(&connections, 1)
+		for {
+			var request streamRequest
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if request.Method != "subscribe" {
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"id": request.Id, "method": "subscribe", "code": 0})
+			if connection == 1 {
+				return // drop the first connection
+			}
+			if channels := request.Params["channels"].([]interface{}); len(channels) != 1 || channels[0] != "trade.ETH_CRO" {
+				t.Errorf("unexpected channels after reconnecting: %v", channels)
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"id":-1,"method":"subscribe","code":0,"result":{"instrument_name":"ETH_CRO","subscription":"trade.ETH_CRO","channel":"trade","data":[{"dataTime":1591710781947,"d":465533583799589409,"s":"BUY","p":2.96,"q":16.0,"t":1591710781946,"i":"ETH_CRO"}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New("", "")
+	client.MarketStreamURL = "ws" + strings.TrimPrefix(server.URL, "http")
+	client.ReconnectPolicy.MinBackoff = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.MarketStream(ctx)
+	if err != nil {
+		t.Fatalf("MarketStream() failed: %v", err)
+	}
+
+	trades, err := stream.SubscribeTrades(ctx, "ETH_CRO")
+	if err != nil {
+		t.Fatalf("SubscribeTrades() failed: %v", err)
+	}
+
+	select {
+	case trade := <-trades:
+		if trade.TradeId != "465533583799589409" || !trade.Price.Equal(dec("2.96")) {
+			t.Errorf("unexpected trade: %+v", trade)
+		}
+	case <-ctx.Done():
+		t.Fatal("SubscribeTrades() did not deliver a trade after reconnecting")
+	}
+
+	stream.Close()
+
+	var states []StreamState
+	for event := range stream.Events() {
+		states = append(states, event.State)
+	}
+	expected := []StreamState{STREAM_CONNECTED, STREAM_DISCONNECTED, STREAM_RECONNECTED, STREAM_CLOSED}
+	if len(states) != len(expected) {
+		t.Fatalf("Events() returned %v, expected %v", states, expected)
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Fatalf("Events() returned %v, expected %v", states, expected)
+		}
+	}
+}
+
+func TestStreamResubscribe(t *testing.T) {
+	// publish more trades than the channel can hold, so the stream blocks on a subscriber that stopped reading
+	trades := make([]string, streamBufferSize+10)
+	for i := range trades {
+		trades[i] = `{"dataTime":1591710781947,"d":465533583799589409,"s":"BUY","p":2.96,"q":16.0,"t":1591710781946,"i":"ETH_CRO"}`
+	}
+	server := newStreamServer(t, `{"id":-1,"method":"subscribe","code":0,"result":{"instrument_name":"ETH_CRO","subscription":"trade.ETH_CRO","channel":"trade","data":[`+strings.Join(trades, ",")+`]}}`)
+	defer server.Close()
+
+	client := New("", "")
+	client.MarketStreamURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.MarketStream(ctx)
+	if err != nil {
+		t.Fatalf("MarketStream() failed: %v", err)
+	}
+	defer stream.Close()
+
+	old, err := stream.SubscribeTrades(ctx, "ETH_CRO")
+	if err != nil {
+		t.Fatalf("SubscribeTrades() failed: %v", err)
+	}
+	// wait for the stream to fill the channel
+	for len(old) < streamBufferSize {
+		select {
+		case <-ctx.Done():
+			t.Fatal("SubscribeTrades() did not fill the channel")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	if _, err := stream.SubscribeTrades(ctx, "ETH_CRO"); err != nil {
+		t.Fatalf("SubscribeTrades() failed to re-subscribe: %v", err)
+	}
+
+	count := 0
+	for range old {
+		count++
+	}
+	if count != streamBufferSize {
+		t.Errorf("old channel delivered %d trades, expected %d", count, streamBufferSize)
+	}
+}
+
+func TestStreamDecodeError(t *testing.T) {
+	server := newStreamServer(t,
+		`{"id":-1,"method":"subscribe","code":0,"result":{"instrument_name":"ETH_CRO","subscription":"ticker.ETH_CRO","channel":"ticker","data":[{"i":"ETH_CRO","b":"not a number"}]}}`,
+		`not json`,
+		`{"id":-1,"method":"subscribe","code":0,"result":{"instrument_name":"ETH_CRO","subscription":"ticker.ETH_CRO","channel":"ticker","data":[{"i":"ETH_CRO","b":2000.5,"k":2001.5,"a":2001,"t":1591704180270}]}}`,
+	)
+	defer server.Close()
+
+	client := New("", "")
+	client.MarketStreamURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.MarketStream(ctx)
+	if err != nil {
+		t.Fatalf("MarketStream() failed: %v", err)
+	}
+
+	tickers, err := stream.SubscribeTicker(ctx, "ETH_CRO")
+	if err != nil {
+		t.Fatalf("SubscribeTicker() failed: %v", err)
+	}
+
+	select {
+	case ticker := <-tickers:
+		if !ticker.Bid.Equal(dec("2000.5")) {
+			t.Errorf("unexpected ticker: %+v", ticker)
+		}
+	case <-ctx.Done():
+		t.Fatal("SubscribeTicker() did not deliver the ticker after the bad messages")
+	}
+
+	stream.Close()
+
+	var states []StreamState
+	for event := range stream.Events() {
+		if event.State == STREAM_ERROR && event.Err == nil {
+			t.Error("ERROR event has no error")
+		}
+		states = append(states, event.State)
+	}
+	expected := []StreamState{STREAM_CONNECTED, STREAM_ERROR, STREAM_ERROR, STREAM_CLOSED}
+	if len(states) != len(expected) {
+		t.Fatalf("Events() returned %v, expected %v", states, expected)
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Fatalf("Events() returned %v, expected %v", states, expected)
+		}
+	}
+}
+
+func TestStreamEmit(t *testing.T) {
+	s := &stream{events: make(chan StreamEvent, 16)}
+
+	// a burst of decode errors must not crowd out the state changes that follow it
+	for i := 0; i < 100; i++ {
+		s.emit(STREAM_ERROR, errors.New("bad message"))
+	}
+	s.emit(STREAM_DISCONNECTED, nil)
+	s.emit(STREAM_RECONNECTED, nil)
+
+	var states []StreamState
+	for len(s.events) > 0 {
+		states = append(states, (<-s.events).State)
+	}
+	if len(states) != 10 || states[8] != STREAM_DISCONNECTED || states[9] != STREAM_RECONNECTED {
+		t.Errorf("Events() returned %v, expected 8 errors followed by %s and %s", states, STREAM_DISCONNECTED, STREAM_RECONNECTED)
+	}
+
+	// when the buffer is full, the latest state change replaces the oldest event
+	for i := 0; i < 20; i++ {
+		s.emit(STREAM_DISCONNECTED, nil)
+	}
+	s.emit(STREAM_RECONNECTED, nil)
+
+	var last StreamEvent
+	for len(s.events) > 0 {
+		last = <-s.events
+	}
+	if last.State != STREAM_RECONNECTED {
+		t.Errorf("Events() ended with %s, expected %s", last.State, STREAM_RECONNECTED)
+	}
+}
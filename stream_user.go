@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UserStream delivers your own orders, trades and balances over an authenticated WebSocket connection that reconnects
+// and re-authenticates automatically. Every subscription returns a channel that is closed when the stream is closed. Read
+// from your channels promptly: the stream blocks when a channel is full.
+type UserStream struct {
+	*stream
+}
+
+// UserStream connects to the user WebSocket and authenticates with the client's API key and secret.
+func (client *Client) UserStream(ctx context.Context) (*UserStream, error) {
+	s, err := dial(ctx, client.UserStreamURL, client.auth, client.ReconnectPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &UserStream{s}, nil
+}
+
+// auth authenticates the stream, see https://exchange-docs.crypto.com/spot/index.html#public-auth
+func (client *Client) auth(ctx context.Context, s *stream) error {
+	var (
+		id    = s.id()
+		nonce = time.Now().UnixNano() / int64(time.Millisecond)
+	)
+	_, err := s.call(ctx, &streamRequest{
+		Id:     id,
+		Method: "public/auth",
+		ApiKey: client.Key,
+		Sig:    client.sign("public/auth", id, nil, nonce),
+		Nonce:  nonce,
+	})
+	return err
+}
+
+// SubscribeOrders subscribes to user.order.{symbol}, e.g. user.order.ETH_CRO
+func (us *UserStream) SubscribeOrders(ctx context.Context, symbol string) (<-chan Order, error) {
+	out := make(chan Order, streamBufferSize)
+	return out, us.subscribe(ctx, fmt.Sprintf("user.order.%s", symbol), forward(out), func() { close(out) })
+}
+
+// SubscribeTrades subscribes to user.trade.{symbol}, e.g. user.trade.ETH_CRO
+func (us *UserStream) SubscribeTrades(ctx context.Context, symbol string) (<-chan Trade, error) {
+	out := make(chan Trade, streamBufferSize)
+	return out, us.subscribe(ctx, fmt.Sprintf("user.trade.%s", symbol), forward(out), func() { close(out) })
+}
+
+// SubscribeBalance subscribes to user.balance
+func (us *UserStream) SubscribeBalance(ctx context.Context) (<-chan Account, error) {
+	out := make(chan Account, streamBufferSize)
+	return out, us.subscribe(ctx, "user.balance", forward(out), func() { close(out) })
+}
@@ -1,11 +1,112 @@
 package crypto
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Rounding determines which way prices and quantities with too many decimals are rounded.
+type Rounding int
+
+const (
+	ROUND_DOWN    Rounding = iota // towards zero
+	ROUND_UP                      // away from zero
+	ROUND_NEAREST                 // to the nearest, half away from zero
+)
+
+func (rounding Rounding) round(d Decimal, decimals int) Decimal {
+	switch rounding {
+	case ROUND_UP:
+		return d.RoundUp(int32(decimals))
+	case ROUND_NEAREST:
+		return d.Round(int32(decimals))
+	default:
+		return d.RoundDown(int32(decimals))
+	}
+}
+
 type Symbol struct {
 	Symbol           string  `json:"instrument_name"`
 	QuoteCurrency    string  `json:"quote_currency"`
 	BaseCurrency     string  `json:"base_currency"`
 	PriceDecimals    int     `json:"price_decimals"`
 	QuantityDecimals int     `json:"quantity_decimals"`
-	MaxQuantity      float64 `json:"max_quantity,string"`
-	MinQuantity      float64 `json:"min_quantity,string"`
+	MaxQuantity      Decimal `json:"max_quantity"`
+	MinQuantity      Decimal `json:"min_quantity"`
+	MinNotional      Decimal `json:"-"` // minimum quantity * price in the quote currency, see Instruments.SetMinNotional
+}
+
+// RoundPrice rounds price to the number of decimals the instrument allows.
+func (symbol *Symbol) RoundPrice(price Decimal, rounding Rounding) Decimal {
+	return rounding.round(price, symbol.PriceDecimals)
+}
+
+// RoundQuantity rounds quantity to the number of decimals the instrument allows.
+func (symbol *Symbol) RoundQuantity(quantity Decimal, rounding Rounding) Decimal {
+	return rounding.round(quantity, symbol.QuantityDecimals)
+}
+
+// Check returns an error that wraps ErrInvalidOrder if req is invalid, or breaks the trading rules of this instrument.
+func (symbol *Symbol) Check(req *OrderRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidOrder, fmt.Sprintf(format, a...))
+	}
+	if req.Symbol != symbol.Symbol {
+		return invalid("order is for %s, not %s", req.Symbol, symbol.Symbol)
+	}
+	for _, price := range []Decimal{req.Price, req.TriggerPrice} {
+		if !price.Equal(symbol.RoundPrice(price, ROUND_DOWN)) {
+			return invalid("price %s has more than %d decimals", price, symbol.PriceDecimals)
+		}
+	}
+	if !req.Quantity.IsZero() {
+		if !req.Quantity.Equal(symbol.RoundQuantity(req.Quantity, ROUND_DOWN)) {
+			return invalid("quantity %s has more than %d decimals", req.Quantity, symbol.QuantityDecimals)
+		}
+		if symbol.MinQuantity.IsPositive() && req.Quantity.LessThan(symbol.MinQuantity) {
+			return invalid("quantity %s is below the minimum of %s %s", req.Quantity, symbol.MinQuantity, symbol.BaseCurrency)
+		}
+		if symbol.MaxQuantity.IsPositive() && req.Quantity.GreaterThan(symbol.MaxQuantity) {
+			return invalid("quantity %s is above the maximum of %s %s", req.Quantity, symbol.MaxQuantity, symbol.BaseCurrency)
+		}
+	}
+	if notional := req.notional(); symbol.MinNotional.IsPositive() && notional.IsPositive() && notional.LessThan(symbol.MinNotional) {
+		return invalid("notional %s is below the minimum of %s %s", notional, symbol.MinNotional, symbol.QuoteCurrency)
+	}
+	return nil
+}
+
+// Normalize returns a copy of req with its prices and quantity rounded to the precision of this instrument, or an error
+// that wraps ErrInvalidOrder if the rounded order still breaks the trading rules.
+func (symbol *Symbol) Normalize(req OrderRequest, rounding Rounding) (*OrderRequest, error) {
+	quantity := req.Quantity
+	req.Price = symbol.RoundPrice(req.Price, rounding)
+	req.TriggerPrice = symbol.RoundPrice(req.TriggerPrice, rounding)
+	req.Quantity = symbol.RoundQuantity(req.Quantity, rounding)
+	if !quantity.IsZero() && req.Quantity.IsZero() {
+		return nil, fmt.Errorf("%w: quantity %s rounds to zero at %d decimals", ErrInvalidOrder, quantity, symbol.QuantityDecimals)
+	}
+	if err := symbol.Check(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ParseSymbol returns the base and quote currency of an instrument name, e.g. BTC_USDT -> BTC, USDT
+func ParseSymbol(symbol string) (base, quote string, err error) {
+	parts := strings.FieldsFunc(symbol, func(r rune) bool {
+		return r == '_' || r == '/'
+	})
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid instrument name: %s", symbol)
+	}
+	return strings.ToUpper(parts[0]), strings.ToUpper(parts[1]), nil
+}
+
+// MakeSymbol returns the instrument name of a base and quote currency, e.g. BTC, USDT -> BTC_USDT
+func MakeSymbol(base, quote string) string {
+	return strings.ToUpper(base) + "_" + strings.ToUpper(quote)
 }
@@ -1,9 +1,53 @@
 package crypto
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Ticker holds the prices of an instrument. The REST API sends them as strings, the WebSocket API sends them as numbers,
+// and either sends null if there aren't any; Decimal decodes all three.
 type Ticker struct {
-	Symbol string  `json:"i,omitempty"` // instrument name, e.g. BTC_USDT, ETH_CRO, etc.
-	Last   float64 `json:"a,string"`    // the price of the latest trade, null if there weren't any trades
-	Volume float64 `json:"v,string"`    // the total 24h traded volume
-	High   float64 `json:"h,string"`    // price of the 24h highest trade
-	Low    float64 `json:"l,string"`    // price of the 24h lowest trade, null if there weren't any trades
+	Symbol    string  `json:"i,omitempty"` // instrument name, e.g. BTC_USDT, ETH_CRO, etc.
+	Bid       Decimal `json:"b"`           // the current best bid price, null if there aren't any bids
+	Ask       Decimal `json:"k"`           // the current best ask price, null if there aren't any asks
+	Last      Decimal `json:"a"`           // the price of the latest trade, null if there weren't any trades
+	Volume    Decimal `json:"v"`           // the total 24h traded volume
+	High      Decimal `json:"h"`           // price of the 24h highest trade
+	Low       Decimal `json:"l"`           // price of the 24h lowest trade, null if there weren't any trades
+	Change    Decimal `json:"c"`           // 24-hour price change, null if there weren't any trades
+	Timestamp int64   `json:"t"`           // timestamp of the data
+}
+
+func (ticker *Ticker) GetTimestamp() time.Time {
+	if ticker.Timestamp > 0 {
+		return time.Unix(ticker.Timestamp/1000, (ticker.Timestamp%1000)*int64(time.Millisecond))
+	}
+	return time.Time{}
+}
+
+// Spread returns the difference between the best ask and the best bid, or zero if either side is empty.
+func (ticker *Ticker) Spread() Decimal {
+	if ticker.Bid.IsZero() || ticker.Ask.IsZero() {
+		return decimal.Zero
+	}
+	return ticker.Ask.Sub(ticker.Bid)
+}
+
+// Mid returns the price halfway between the best bid and the best ask, or zero if either side is empty.
+func (ticker *Ticker) Mid() Decimal {
+	if ticker.Bid.IsZero() || ticker.Ask.IsZero() {
+		return decimal.Zero
+	}
+	return ticker.Bid.Add(ticker.Ask).Div(decimal.NewFromInt(2))
+}
+
+// ChangePercent returns the 24-hour price change as a percentage of the price 24 hours ago.
+func (ticker *Ticker) ChangePercent() Decimal {
+	open := ticker.Last.Sub(ticker.Change)
+	if open.IsZero() {
+		return decimal.Zero
+	}
+	return ticker.Change.Div(open).Mul(decimal.NewFromInt(100))
 }
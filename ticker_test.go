@@ -0,0 +1,32 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTickerHelpers(t *testing.T) {
+	var ticker Ticker
+	if err := json.Unmarshal([]byte(`{"i":"CRO_BTC","b":"0.00000998","k":"0.00001002","a":"0.00001000","t":1591704180270,"v":"1000","h":"0.00001050","l":"0.00000950","c":"0.00000200"}`), &ticker); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if spread := ticker.Spread(); !spread.Equal(dec("0.00000004")) {
+		t.Errorf("Spread() returned %v, expected 0.00000004", spread)
+	}
+	if mid := ticker.Mid(); !mid.Equal(dec("0.00001")) {
+		t.Errorf("Mid() returned %v, expected 0.00001", mid)
+	}
+	if pct := ticker.ChangePercent(); !pct.Equal(dec("25")) {
+		t.Errorf("ChangePercent() returned %v, expected 25", pct)
+	}
+}
+
+func TestTickerNumbers(t *testing.T) {
+	var ticker Ticker
+	if err := json.Unmarshal([]byte(`{"i":"CRO_BTC","b":0.00000998,"k":0.00001002,"a":null,"t":1591704180270}`), &ticker); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if !ticker.Bid.Equal(dec("0.00000998")) || !ticker.Ask.Equal(dec("0.00001002")) || !ticker.Last.IsZero() {
+		t.Errorf("unexpected ticker: %+v", ticker)
+	}
+}
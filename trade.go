@@ -1,15 +1,18 @@
 package crypto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Trade struct {
 	Side        OrderSide `json:"side"`            // BUY or SELL
 	Symbol      string    `json:"instrument_name"` // e.g. ETH_CRO, BTC_USDT
-	Fee         float64   `json:"fee"`             // trade fee
+	Fee         Decimal   `json:"fee"`             // trade fee
 	TradeId     string    `json:"trade_id"`        // trade ID
 	CreatedAt   int64     `json:"create_time"`     // trade creation time
-	Price       float64   `json:"traded_price"`    // executed trade price
-	Quantity    float64   `json:"traded_quantity"` // executed trade quantity
+	Price       Decimal   `json:"traded_price"`    // executed trade price
+	Quantity    Decimal   `json:"traded_quantity"` // executed trade quantity
 	FeeCurrency string    `json:"fee_currency"`    // currency used for the fees (e.g. CRO)
 	OrderId     string    `json:"order_id"`
 }
@@ -20,3 +23,72 @@ func (trade *Trade) GetCreatedAt() time.Time {
 	}
 	return time.Time{}
 }
+
+// before returns true if this trade was executed before the other one. Trades executed in the same millisecond are
+// ordered by their (numeric) trade ID.
+func (trade *Trade) before(other *Trade) bool {
+	if trade.CreatedAt != other.CreatedAt {
+		return trade.CreatedAt < other.CreatedAt
+	}
+	return lessNumeric(trade.TradeId, other.TradeId)
+}
+
+// lessNumeric compares two unsigned integers that are too big for int64.
+func lessNumeric(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// TradeCursor marks the last trade you have seen, so you can resume from there with MyTradesAfter.
+type TradeCursor struct {
+	TradeId   string `json:"trade_id"`
+	CreatedAt int64  `json:"create_time"`
+}
+
+func NewTradeCursor(trade *Trade) TradeCursor {
+	return TradeCursor{
+		TradeId:   trade.TradeId,
+		CreatedAt: trade.CreatedAt,
+	}
+}
+
+func (cursor *TradeCursor) time() time.Time {
+	return time.Unix(cursor.CreatedAt/1000, (cursor.CreatedAt%1000)*int64(time.Millisecond))
+}
+
+// before returns true if the cursor is before trade, e.g. you haven't seen the trade yet.
+func (cursor *TradeCursor) before(trade *Trade) bool {
+	return (&Trade{TradeId: cursor.TradeId, CreatedAt: cursor.CreatedAt}).before(trade)
+}
+
+type PublicTrade struct {
+	Symbol   string    // e.g. ETH_CRO, BTC_USDT
+	TradeId  string    // trade ID
+	Side     OrderSide // side of the taker, BUY or SELL
+	Price    Decimal   // trade price
+	Quantity Decimal   // trade quantity
+	Time     time.Time // trade timestamp
+}
+
+func (trade *PublicTrade) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		I string      `json:"i"` // instrument name
+		D json.Number `json:"d"` // trade ID
+		S OrderSide   `json:"s"` // side
+		P Decimal     `json:"p"` // price
+		Q Decimal     `json:"q"` // quantity
+		T int64       `json:"t"` // trade timestamp (Unix timestamp in ms)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	trade.Symbol = raw.I
+	trade.TradeId = raw.D.String()
+	trade.Side = raw.S
+	trade.Price = raw.P
+	trade.Quantity = raw.Q
+	trade.Time = time.Unix(raw.T/1000, (raw.T%1000)*int64(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidWithdrawal is returned when a withdrawal fails validation, before anything is sent to the exchange.
+var ErrInvalidWithdrawal = errors.New("invalid withdrawal")
+
+// ErrWithdrawalNotAllowed is returned when you withdraw to an address that is not on the allow list of the Client.
+var ErrWithdrawalNotAllowed = errors.New("withdrawal address not allowed")
+
+type WithdrawalStatus string
+
+const (
+	WITHDRAWAL_STATUS_PENDING             WithdrawalStatus = "0"
+	WITHDRAWAL_STATUS_PROCESSING          WithdrawalStatus = "1"
+	WITHDRAWAL_STATUS_REJECTED            WithdrawalStatus = "2"
+	WITHDRAWAL_STATUS_PAYMENT_IN_PROGRESS WithdrawalStatus = "3"
+	WITHDRAWAL_STATUS_PAYMENT_FAILED      WithdrawalStatus = "4"
+	WITHDRAWAL_STATUS_COMPLETED           WithdrawalStatus = "5"
+	WITHDRAWAL_STATUS_CANCELED            WithdrawalStatus = "6"
+)
+
+type Withdrawal struct {
+	Id            string           `json:"id"`                   // withdrawal ID
+	ClientWid     string           `json:"client_wid,omitempty"` // your own withdrawal ID, if any
+	Currency      string           `json:"currency"`             // e.g. CRO
+	Amount        Decimal          `json:"amount"`               // withdrawn amount
+	Fee           Decimal          `json:"fee"`                  // withdrawal fee
+	Address       string           `json:"address"`              // the address the withdrawal was sent to
+	Network       string           `json:"network_id,omitempty"` // the network (or chain) the withdrawal was sent on
+	Status        WithdrawalStatus `json:"status"`               // 0 - pending, 1 - processing, 2 - rejected, 3 - payment in progress, 4 - payment failed, 5 - completed, 6 - canceled
+	TransactionId string           `json:"txid,omitempty"`       // transaction hash, once the withdrawal has been sent
+	CreatedAt     int64            `json:"create_time"`          // creation time
+	UpdatedAt     int64            `json:"update_time"`          // last update time
+}
+
+func (withdrawal *Withdrawal) UnmarshalJSON(data []byte) error {
+	type Alias Withdrawal
+	var raw struct {
+		*Alias
+		Id     json.Number `json:"id"`     // private/create-withdrawal sends a number, private/get-withdrawal-history a string
+		Symbol string      `json:"symbol"` // private/create-withdrawal calls the currency symbol
+	}
+	raw.Alias = (*Alias)(withdrawal)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	withdrawal.Id = raw.Id.String()
+	if withdrawal.Currency == "" {
+		withdrawal.Currency = raw.Symbol
+	}
+	return nil
+}
+
+func (withdrawal *Withdrawal) GetCreatedAt() time.Time {
+	if withdrawal.CreatedAt > 0 {
+		return time.Unix(withdrawal.CreatedAt/1000, 0)
+	}
+	return time.Time{}
+}
+
+func (withdrawal *Withdrawal) GetUpdatedAt() time.Time {
+	if withdrawal.UpdatedAt > 0 {
+		return time.Unix(withdrawal.UpdatedAt/1000, 0)
+	}
+	return time.Time{}
+}
+
+type CurrencyNetwork struct {
+	Network              string  `json:"network_id"`            // e.g. ETH or CRO
+	WithdrawalFee        Decimal `json:"withdrawal_fee"`        // zero if unknown
+	WithdrawEnabled      bool    `json:"withdraw_enabled"`      // false if you cannot withdraw on this network
+	MinWithdrawalAmount  Decimal `json:"min_withdrawal_amount"` // the smallest amount you can withdraw on this network
+	DepositEnabled       bool    `json:"deposit_enabled"`       // false if you cannot deposit on this network
+	ConfirmationRequired int     `json:"confirmation_required"` // the number of confirmations before a deposit arrives
+}
+
+type Currency struct {
+	Currency       string            `json:"-"`               // e.g. CRO
+	FullName       string            `json:"full_name"`       // e.g. Crypto.com Coin
+	DefaultNetwork string            `json:"default_network"` // empty if the currency has no default network
+	Networks       []CurrencyNetwork `json:"network_list"`
+}
+
+// GetNetwork returns the network with the given ID, or the default network if the ID is empty.
+func (currency *Currency) GetNetwork(network string) (*CurrencyNetwork, error) {
+	if network == "" {
+		network = currency.DefaultNetwork
+	}
+	if network == "" && len(currency.Networks) == 1 {
+		network = currency.Networks[0].Network
+	}
+	if network == "" {
+		return nil, fmt.Errorf("%w: %s has more than one network and no default network", ErrInvalidWithdrawal, currency.Currency)
+	}
+	for i := range currency.Networks {
+		if currency.Networks[i].Network == network {
+			return &currency.Networks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not available on network %s", ErrInvalidWithdrawal, currency.Currency, network)
+}
+
+// CheckWithdrawal returns an error that wraps ErrInvalidWithdrawal if you cannot withdraw amount on the network.
+func (currency *Currency) CheckWithdrawal(amount Decimal, network string) error {
+	info, err := currency.GetNetwork(network)
+	if err != nil {
+		return err
+	}
+	if !info.WithdrawEnabled {
+		return fmt.Errorf("%w: withdrawals of %s on network %s are disabled", ErrInvalidWithdrawal, currency.Currency, info.Network)
+	}
+	if amount.LessThan(info.MinWithdrawalAmount) {
+		return fmt.Errorf("%w: %s %s is below the minimum withdrawal of %s %s on network %s", ErrInvalidWithdrawal, amount, currency.Currency, info.MinWithdrawalAmount, currency.Currency, info.Network)
+	}
+	return nil
+}
+
+// AllowList holds the addresses a Client may withdraw to. It is safe for concurrent use.
+type AllowList struct {
+	mutex     sync.RWMutex
+	addresses map[string]bool
+}
+
+func allowListKey(currency, network, address, tag string) string {
+	return strings.ToUpper(currency) + " " + strings.ToUpper(network) + " " + address + " " + tag
+}
+
+// Allow registers an address (and tag or memo, if the currency needs one) you can withdraw currency to on network.
+func (list *AllowList) Allow(currency, network, address, tag string) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	if list.addresses == nil {
+		list.addresses = make(map[string]bool)
+	}
+	list.addresses[allowListKey(currency, network, address, tag)] = true
+}
+
+// Allowed returns true if the address (and tag) has been registered for currency on network.
+func (list *AllowList) Allowed(currency, network, address, tag string) bool {
+	if list == nil {
+		return false
+	}
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+	return list.addresses[allowListKey(currency, network, address, tag)]
+}
@@ -0,0 +1,158 @@
+package crypto
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func currencyNetworks(params map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"update_time": 1641151604000,
+		"currency_map": map[string]interface{}{
+			"XRP": map[string]interface{}{
+				"full_name":       "XRP",
+				"default_network": "XRP",
+				"network_list": []map[string]interface{}{
+					{"network_id": "XRP", "withdrawal_fee": 0.25, "withdraw_enabled": true, "min_withdrawal_amount": 22, "deposit_enabled": true, "confirmation_required": 0},
+					{"network_id": "BSC", "withdrawal_fee": nil, "withdraw_enabled": false, "min_withdrawal_amount": 10, "deposit_enabled": true, "confirmation_required": 15},
+				},
+			},
+			"AGLD": map[string]interface{}{
+				"full_name":       "Adventure Gold",
+				"default_network": nil,
+				"network_list": []map[string]interface{}{
+					{"network_id": "ETH", "withdrawal_fee": nil, "withdraw_enabled": true, "min_withdrawal_amount": 10.0, "deposit_enabled": true, "confirmation_required": 12},
+				},
+			},
+		},
+	}
+}
+
+func TestCurrencyNetworks(t *testing.T) {
+	server := newTestServer(t, map[string]testHandler{
+		"private/get-currency-networks": currencyNetworks,
+	})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	currencies, err := client.CurrencyNetworks()
+	if err != nil {
+		t.Fatalf("CurrencyNetworks() failed: %v", err)
+	}
+	xrp, ok := currencies["XRP"]
+	if !ok || xrp.Currency != "XRP" || len(xrp.Networks) != 2 || !xrp.Networks[0].WithdrawalFee.Equal(dec("0.25")) || !xrp.Networks[1].WithdrawalFee.IsZero() {
+		t.Fatalf("unexpected currency: %+v", xrp)
+	}
+
+	if err := xrp.CheckWithdrawal(dec("25"), ""); err != nil {
+		t.Errorf("CheckWithdrawal() on the default network failed: %v", err)
+	}
+	for _, network := range []string{"BSC", "ETH"} {
+		if err := xrp.CheckWithdrawal(dec("25"), network); !errors.Is(err, ErrInvalidWithdrawal) {
+			t.Errorf("CheckWithdrawal() on %s returned %v, expected %v", network, err, ErrInvalidWithdrawal)
+		}
+	}
+	if err := xrp.CheckWithdrawal(dec("21.99"), "XRP"); !errors.Is(err, ErrInvalidWithdrawal) {
+		t.Errorf("CheckWithdrawal() below the minimum returned %v, expected %v", err, ErrInvalidWithdrawal)
+	}
+
+	agld := currencies["AGLD"]
+	if network, err := agld.GetNetwork(""); err != nil || network.Network != "ETH" || network.ConfirmationRequired != 12 {
+		t.Errorf("GetNetwork() returned %+v, %v", network, err)
+	}
+}
+
+func TestCreateWithdrawal(t *testing.T) {
+	var calls int32
+
+	server := newTestServer(t, map[string]testHandler{
+		"private/get-currency-networks": currencyNetworks,
+		"private/create-withdrawal": func(params map[string]interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			if params["currency"] != "XRP" || params["amount"] != 25.0 || params["address_tag"] != "12345" || params["network_id"] != "XRP" || params["client_wid"] != "sweep-1" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			return map[string]interface{}{
+				"id":          2220,
+				"amount":      25,
+				"fee":         0.25,
+				"symbol":      "XRP",
+				"address":     "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh",
+				"client_wid":  "sweep-1",
+				"create_time": 1607063412000,
+			}
+		},
+	})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	if _, err := client.CreateWithdrawal("XRP", dec("12.5"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "", "sweep-1"); !errors.Is(err, ErrWithdrawalNotAllowed) {
+		t.Errorf("CreateWithdrawal() returned %v, expected %v", err, ErrWithdrawalNotAllowed)
+	}
+
+	client.Withdrawals.Allow("xrp", "XRP", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345")
+
+	if _, err := client.CreateWithdrawal("XRP", dec("12.5"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "54321", "", "sweep-1"); !errors.Is(err, ErrWithdrawalNotAllowed) {
+		t.Errorf("CreateWithdrawal() with another tag returned %v, expected %v", err, ErrWithdrawalNotAllowed)
+	}
+	if _, err := client.CreateWithdrawal("XRP", dec("0"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "", "sweep-1"); !errors.Is(err, ErrInvalidWithdrawal) {
+		t.Errorf("CreateWithdrawal() with a zero amount returned %v, expected %v", err, ErrInvalidWithdrawal)
+	}
+	if _, err := client.CreateWithdrawal("XRP", dec("12.5"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "BSC", "sweep-1"); !errors.Is(err, ErrWithdrawalNotAllowed) {
+		t.Errorf("CreateWithdrawal() on another network returned %v, expected %v", err, ErrWithdrawalNotAllowed)
+	}
+	client.Withdrawals.Allow("XRP", "BSC", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345")
+	if _, err := client.CreateWithdrawal("XRP", dec("12.5"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "BSC", "sweep-1"); !errors.Is(err, ErrInvalidWithdrawal) {
+		t.Errorf("CreateWithdrawal() on a disabled network returned %v, expected %v", err, ErrInvalidWithdrawal)
+	}
+	if _, err := client.CreateWithdrawal("XRP", dec("12.5"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "XRP", "sweep-1"); !errors.Is(err, ErrInvalidWithdrawal) {
+		t.Errorf("CreateWithdrawal() below the minimum returned %v, expected %v", err, ErrInvalidWithdrawal)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("CreateWithdrawal() called the exchange %d times for a refused withdrawal", n)
+	}
+
+	withdrawal, err := client.CreateWithdrawal("XRP", dec("25"), "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "12345", "", "sweep-1")
+	if err != nil {
+		t.Fatalf("CreateWithdrawal() failed: %v", err)
+	}
+	if withdrawal.Id != "2220" || withdrawal.Currency != "XRP" || !withdrawal.Fee.Equal(dec("0.25")) || withdrawal.ClientWid != "sweep-1" {
+		t.Errorf("unexpected withdrawal: %+v", withdrawal)
+	}
+
+	if !client.idempotent("private/create-withdrawal", map[string]interface{}{"client_wid": "sweep-1"}) {
+		t.Error("a withdrawal with a client_wid is not idempotent")
+	}
+	if client.idempotent("private/create-withdrawal", map[string]interface{}{}) {
+		t.Error("a withdrawal without a client_wid is idempotent")
+	}
+}
+
+func TestWithdrawalHistory(t *testing.T) {
+	server := newTestServer(t, map[string]testHandler{"private/get-withdrawal-history": func(params map[string]interface{}) interface{} {
+		if params["status"] != string(WITHDRAWAL_STATUS_COMPLETED) {
+			t.Errorf("unexpected params: %v", params)
+		}
+		return map[string]interface{}{
+			"withdrawal_list": []map[string]interface{}{
+				{"currency": "CRO", "client_wid": "", "fee": 1.0, "create_time": 1607063412000, "id": "2", "update_time": 1607063460000, "amount": "100", "address": "2NBqqD5GRJ8wHy1PYyCXTe9ke5226FhavBf", "status": "5", "txid": "0xabc", "network_id": "CRO"},
+				{"currency": "CRO", "client_wid": "sweep-1", "fee": 1.0, "create_time": 1607063411000, "id": "1", "update_time": 1607063460000, "amount": "50", "address": "2NBqqD5GRJ8wHy1PYyCXTe9ke5226FhavBf", "status": "5"},
+			},
+		}
+	}})
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	withdrawals, err := client.WithdrawalHistory("CRO", time.Time{}, time.Time{}, WITHDRAWAL_STATUS_COMPLETED)
+	if err != nil {
+		t.Fatalf("WithdrawalHistory() failed: %v", err)
+	}
+	if len(withdrawals) != 2 || withdrawals[0].Id != "1" || withdrawals[1].TransactionId != "0xabc" || withdrawals[1].Network != "CRO" || !withdrawals[1].Amount.Equal(dec("100")) {
+		t.Errorf("unexpected withdrawals: %+v", withdrawals)
+	}
+}